@@ -104,6 +104,60 @@ type Annotation struct {
 	//	}
 	//
 	Checks map[string]string `json:"checks,omitempty"`
+
+	// Storage defines the storage mode of a column. In PostgreSQL, the following
+	// annotation is applied on migration as follows:
+	//
+	//	field.Bytes("data").
+	//		Annotations(entsql.Storage("EXTERNAL"))
+	//
+	//	ALTER TABLE "t" ALTER COLUMN "data" SET STORAGE EXTERNAL
+	//
+	Storage string `json:"storage,omitempty"`
+
+	// JoinTable overrides the default join table name of M2M edges. For example:
+	//
+	//	edge.To("tags", Tag.Type).
+	//		Annotations(entsql.JoinTable("user_labels", "user_id", "label_id"))
+	//
+	JoinTable string `json:"join_table,omitempty"`
+
+	// JoinColumns overrides the default column names of the join table of M2M edges.
+	// The 1st column references the table of the edge owner (the "To" edge), and the
+	// 2nd column references the table of the edge type (the "From" edge).
+	JoinColumns []string `json:"join_columns,omitempty"`
+
+	// Deferrable defines the foreign-key constraint of an edge as deferrable, i.e. its
+	// checking can be postponed to the end of the transaction. Currently, it is supported
+	// only by PostgreSQL. For example:
+	//
+	//	edge.To("sibling", Node.Type).
+	//		Annotations(entsql.Deferrable(true))
+	//
+	Deferrable bool `json:"deferrable,omitempty"`
+
+	// InitiallyDeferred defines the foreign-key constraint of an edge as deferrable, and
+	// deferred by default to the end of the transaction. For example:
+	//
+	//	edge.To("sibling", Node.Type).
+	//		Annotations(entsql.InitiallyDeferred(true))
+	//
+	//	FOREIGN KEY ... DEFERRABLE INITIALLY DEFERRED
+	//
+	InitiallyDeferred bool `json:"initially_deferred,omitempty"`
+
+	// Unlogged defines the table as an UNLOGGED table. Data written to unlogged tables
+	// is not written to the write-ahead log, which makes them faster than ordinary tables,
+	// but they are truncated after a crash. Currently, it is supported only by PostgreSQL.
+	// For example:
+	//
+	//	entsql.Annotation{
+	//		Unlogged: true,
+	//	}
+	//
+	//	ALTER TABLE "t" SET UNLOGGED
+	//
+	Unlogged bool `json:"unlogged,omitempty"`
 }
 
 // Name describes the annotation name.
@@ -148,6 +202,24 @@ func (a Annotation) Merge(other schema.Annotation) schema.Annotation {
 	if c := ant.Check; c != "" {
 		a.Check = c
 	}
+	if s := ant.Storage; s != "" {
+		a.Storage = s
+	}
+	if t := ant.JoinTable; t != "" {
+		a.JoinTable = t
+	}
+	if c := ant.JoinColumns; len(c) > 0 {
+		a.JoinColumns = c
+	}
+	if ant.Deferrable {
+		a.Deferrable = true
+	}
+	if ant.InitiallyDeferred {
+		a.InitiallyDeferred = true
+	}
+	if ant.Unlogged {
+		a.Unlogged = true
+	}
 	if checks := ant.Checks; len(checks) > 0 {
 		if a.Checks == nil {
 			a.Checks = make(map[string]string)
@@ -159,6 +231,61 @@ func (a Annotation) Merge(other schema.Annotation) schema.Annotation {
 	return a
 }
 
+// Storage returns a new annotation with the storage mode of a column (e.g. PLAIN,
+// MAIN, EXTERNAL or EXTENDED). Currently, it is supported only by PostgreSQL.
+//
+//	field.String("body").
+//		Annotations(entsql.Storage("EXTENDED"))
+//
+func Storage(s string) *Annotation {
+	return &Annotation{
+		Storage: s,
+	}
+}
+
+// JoinTable returns a new annotation for overriding the default name and
+// columns of the join table of M2M edges. For example:
+//
+//	edge.To("tags", Tag.Type).
+//		Annotations(entsql.JoinTable("user_labels", "user_id", "label_id"))
+//
+//	CREATE TABLE `user_labels` (`user_id` bigint, `label_id` bigint, ...)
+//
+func JoinTable(name, to, from string) *Annotation {
+	return &Annotation{
+		JoinTable:   name,
+		JoinColumns: []string{to, from},
+	}
+}
+
+// Deferrable returns a new annotation for defining the foreign-key
+// constraint of an edge as deferrable. For example:
+//
+//	edge.To("sibling", Node.Type).
+//		Annotations(entsql.Deferrable(true))
+//
+//	FOREIGN KEY ... DEFERRABLE
+//
+func Deferrable(b bool) *Annotation {
+	return &Annotation{
+		Deferrable: b,
+	}
+}
+
+// InitiallyDeferred returns a new annotation for defining the foreign-key
+// constraint of an edge as deferred by default. For example:
+//
+//	edge.To("sibling", Node.Type).
+//		Annotations(entsql.Deferrable(true), entsql.InitiallyDeferred(true))
+//
+//	FOREIGN KEY ... DEFERRABLE INITIALLY DEFERRED
+//
+func InitiallyDeferred(b bool) *Annotation {
+	return &Annotation{
+		InitiallyDeferred: b,
+	}
+}
+
 var _ interface {
 	schema.Annotation
 	schema.Merger
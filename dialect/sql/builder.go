@@ -363,6 +363,16 @@ func (t *TableAlter) DropConstraint(ident string) *TableAlter {
 	return t
 }
 
+// AlterConstraint appends the `ALTER CONSTRAINT` clause to the given `ALTER TABLE` statement.
+//
+//	AlterTable("pets").
+//		AlterConstraint("pets_owner", "DEFERRABLE INITIALLY DEFERRED")
+//
+func (t *TableAlter) AlterConstraint(ident, spec string) *TableAlter {
+	t.Queries = append(t.Queries, Raw(fmt.Sprintf("ALTER CONSTRAINT %s %s", t.Quote(ident), spec)))
+	return t
+}
+
 // DropForeignKey appends the `DROP FOREIGN KEY` clause to the given `ALTER TABLE` statement.
 func (t *TableAlter) DropForeignKey(ident string) *TableAlter {
 	t.Queries = append(t.Queries, Raw(fmt.Sprintf("DROP FOREIGN KEY %s", t.Quote(ident))))
@@ -418,10 +428,11 @@ func (i *IndexAlter) Query() (string, []interface{}) {
 // ForeignKeyBuilder is the builder for the foreign-key constraint clause.
 type ForeignKeyBuilder struct {
 	Builder
-	symbol  string
-	columns []string
-	actions []string
-	ref     *ReferenceBuilder
+	symbol     string
+	columns    []string
+	actions    []string
+	ref        *ReferenceBuilder
+	deferrable string
 }
 
 // ForeignKey returns a builder for the foreign-key constraint clause in create/alter table statements.
@@ -469,6 +480,23 @@ func (fk *ForeignKeyBuilder) OnUpdate(action string) *ForeignKeyBuilder {
 	return fk
 }
 
+// Deferrable marks the constraint as deferrable, and optionally as initially deferred.
+// That is, checking the constraint can be postponed to the end of the transaction.
+// Note that this option is ignored by MySQL, as it does not support deferred constraints.
+//
+//	ForeignKey().
+//		Columns("sibling_id").
+//		Reference(Reference().Table("nodes").Columns("id")).
+//		Deferrable(true)
+//
+func (fk *ForeignKeyBuilder) Deferrable(initially bool) *ForeignKeyBuilder {
+	fk.deferrable = "DEFERRABLE"
+	if initially {
+		fk.deferrable += " INITIALLY DEFERRED"
+	}
+	return fk
+}
+
 // Query returns query representation of a foreign key constraint.
 func (fk *ForeignKeyBuilder) Query() (string, []interface{}) {
 	if fk.symbol != "" {
@@ -482,6 +510,9 @@ func (fk *ForeignKeyBuilder) Query() (string, []interface{}) {
 	for _, action := range fk.actions {
 		fk.Pad().WriteString(action)
 	}
+	if fk.deferrable != "" && !fk.mysql() {
+		fk.Pad().WriteString(fk.deferrable)
+	}
 	return fk.String(), fk.args
 }
 
@@ -523,12 +554,13 @@ func (r *ReferenceBuilder) Query() (string, []interface{}) {
 // IndexBuilder is a builder for `CREATE INDEX` statement.
 type IndexBuilder struct {
 	Builder
-	name    string
-	unique  bool
-	exists  bool
-	table   string
-	method  string
-	columns []string
+	name       string
+	unique     bool
+	exists     bool
+	concurrent bool
+	table      string
+	method     string
+	columns    []string
 }
 
 // CreateIndex creates a builder for the `CREATE INDEX` statement.
@@ -561,6 +593,14 @@ func (i *IndexBuilder) Unique() *IndexBuilder {
 	return i
 }
 
+// Concurrently appends the `CONCURRENTLY` clause to the `CREATE INDEX` statement,
+// for creating the index without locking the table against writes. Note that
+// this option is supported only by Postgres, and it's ignored by other dialects.
+func (i *IndexBuilder) Concurrently() *IndexBuilder {
+	i.concurrent = true
+	return i
+}
+
 // Table defines the table for the index.
 func (i *IndexBuilder) Table(table string) *IndexBuilder {
 	i.table = table
@@ -592,6 +632,9 @@ func (i *IndexBuilder) Query() (string, []interface{}) {
 		i.WriteString("UNIQUE ")
 	}
 	i.WriteString("INDEX ")
+	if i.concurrent && i.postgres() {
+		i.WriteString("CONCURRENTLY ")
+	}
 	if i.exists {
 		i.WriteString("IF NOT EXISTS ")
 	}
@@ -2067,6 +2110,121 @@ func (s *SelectTable) ref() string {
 // implement the table view.
 func (*SelectTable) view() {}
 
+// ValuesTable returns a new selector that selects the given rows from a `VALUES` clause.
+// The returned selector can be used as an inline table, and joined with other selectors.
+//
+//	t1 := Table("users")
+//	t2 := ValuesTable([][]driver.Value{{1, "a"}, {2, "b"}}, []string{"id", "name"}).As("t")
+//	Select(t1.C("name"), t2.C("name")).
+//		From(t1).
+//		Join(t2).
+//		On(t1.C("id"), t2.C("id"))
+//
+func ValuesTable(rows [][]driver.Value, columns []string) *Selector {
+	s := Select().From(&valuesTable{rows: rows, columns: columns})
+	for _, err := range checkValues(rows, columns) {
+		s.AddError(err)
+	}
+	return s
+}
+
+// ValuesLazy sets the source of the `SELECT` statement to a `VALUES` clause, similar
+// to ValuesTable. Unlike ValuesTable, the rows and the columns of the clause are not
+// materialized upfront, but the given function is called only when the query is built.
+//
+//	t := Dialect(dialect.Postgres).
+//		Select().
+//		ValuesLazy(func() ([][]driver.Value, []string) {
+//			return loadRows(), []string{"id", "name"}
+//		}).
+//		As("t")
+//
+func (s *Selector) ValuesLazy(fn func() (rows [][]driver.Value, columns []string)) *Selector {
+	return s.From(&valuesTable{fn: fn})
+}
+
+// checkValues checks that the given rows and columns form a valid `VALUES` clause.
+func checkValues(rows [][]driver.Value, columns []string) (errs []error) {
+	if len(rows) == 0 {
+		errs = append(errs, errors.New("sql: missing rows for VALUES table"))
+	}
+	for i := range rows {
+		if len(rows[i]) != len(columns) {
+			errs = append(errs, fmt.Errorf("sql: row %d of VALUES table has %d values, but %d columns were given", i, len(rows[i]), len(columns)))
+		}
+	}
+	return errs
+}
+
+// valuesTable is a table view for the `VALUES` clause.
+type valuesTable struct {
+	rows    [][]driver.Value
+	columns []string
+	// fn, if set, evaluates the rows and the columns when the clause is built.
+	fn func() ([][]driver.Value, []string)
+}
+
+// join writes the `VALUES` clause to the given builder with the given alias.
+func (v *valuesTable) join(b *Builder, as string) {
+	if as == "" {
+		as = "t"
+	}
+	if v.fn != nil {
+		rows, columns := v.fn()
+		for _, err := range checkValues(rows, columns) {
+			b.AddError(err)
+		}
+		v = &valuesTable{rows: rows, columns: columns}
+	}
+	// SQLite does not support column aliases for the `VALUES` clause.
+	if b.Dialect() == dialect.SQLite {
+		b.Nested(func(b *Builder) {
+			for i, row := range v.rows {
+				if i > 0 {
+					b.WriteString(" UNION ALL ")
+				}
+				b.WriteString("SELECT ")
+				for j := range row {
+					if j > 0 {
+						b.Comma()
+					}
+					b.Arg(row[j])
+					if i == 0 && j < len(v.columns) {
+						b.WriteString(" AS ").Ident(v.columns[j])
+					}
+				}
+			}
+		})
+		b.WriteString(" AS ").Ident(as)
+		return
+	}
+	b.Nested(func(b *Builder) {
+		b.WriteString("VALUES ")
+		for i, row := range v.rows {
+			if i > 0 {
+				b.Comma()
+			}
+			if b.mysql() {
+				b.WriteString("ROW")
+			}
+			b.Nested(func(b *Builder) {
+				for j := range row {
+					if j > 0 {
+						b.Comma()
+					}
+					b.Arg(row[j])
+				}
+			})
+		}
+	})
+	b.WriteString(" AS ").Ident(as).Nested(func(b *Builder) {
+		b.IdentComma(v.columns...)
+	})
+}
+
+// implement the table view.
+func (*valuesTable) view() {}
+
 // join table option.
 type join struct {
 	on    *Predicate
@@ -2088,23 +2246,26 @@ type Selector struct {
 	Builder
 	// ctx stores contextual data typically from
 	// generated code such as alternate table schemas.
-	ctx       context.Context
-	as        string
-	selection []interface{}
-	from      TableView
-	joins     []join
-	where     *Predicate
-	or        bool
-	not       bool
-	order     []interface{}
-	group     []string
-	having    *Predicate
-	limit     *int
-	offset    *int
-	distinct  bool
-	union     []union
-	prefix    Queries
-	lock      *LockOptions
+	ctx        context.Context
+	as         string
+	selection  []interface{}
+	from       TableView
+	joins      []join
+	where      *Predicate
+	or         bool
+	not        bool
+	order      []interface{}
+	group      []string
+	having     *Predicate
+	limit      *int
+	offset     *int
+	distinct   bool
+	distinctOn []string
+	union      []union
+	setOp      *setOp
+	prefix     Queries
+	lock       *LockOptions
+	comment    string
 }
 
 // WithContext sets the context into the *Selector.
@@ -2245,6 +2406,35 @@ func (s *Selector) SetDistinct(v bool) *Selector {
 	return s
 }
 
+// SelectDistinctOn adds the `DISTINCT ON (columns)` clause to the `SELECT` statement,
+// for returning only the first row of each set of rows with the same values in the
+// given columns. Note that this clause is supported only by Postgres, and building
+// the query in other dialects fails.
+//
+//	Dialect(dialect.Postgres).
+//		Select("owner_id", "name").
+//		SelectDistinctOn("owner_id").
+//		From(Table("pets")).
+//		OrderBy("owner_id", Desc("created_at"))
+//
+func (s *Selector) SelectDistinctOn(columns ...string) *Selector {
+	s.distinctOn = append(s.distinctOn, columns...)
+	return s
+}
+
+// Comment sets a comment that is prepended to the `SELECT` statement.
+// For example, used for correlating queries in the database logs:
+//
+//	client.User.Query().
+//		Modify(func(s *sql.Selector) {
+//			s.Comment("user:list:active")
+//		})
+//
+func (s *Selector) Comment(text string) *Selector {
+	s.comment = text
+	return s
+}
+
 // Limit adds the `LIMIT` clause to the `SELECT` statement.
 func (s *Selector) Limit(limit int) *Selector {
 	s.limit = &limit
@@ -2318,6 +2508,8 @@ func (s *Selector) TableName() string {
 		return view.name
 	case *Selector:
 		return view.as
+	case *valuesTable:
+		return s.as
 	default:
 		panic(fmt.Sprintf("unhandled TableView type %T", s.from))
 	}
@@ -2400,12 +2592,120 @@ func (s *Selector) UnionDistinct(t TableView) *Selector {
 	return s
 }
 
+// setOp describes a set operation between two queries.
+type setOp struct {
+	op       string
+	lhs, rhs *Selector
+}
+
+type (
+	// SetOpOptions defines the options of the EXCEPT and INTERSECT set operations.
+	SetOpOptions struct {
+		// MySQL allows generating the set operation for MySQL, that supports
+		// EXCEPT and INTERSECT only from version 8.0.31. Since the builder is not
+		// aware of the database version, it fails on MySQL unless it is set.
+		MySQL bool
+	}
+	// SetOpOption allows configuring the SetOpOptions using functional options.
+	SetOpOption func(*SetOpOptions)
+)
+
+// WithMySQLSetOp allows generating the EXCEPT and INTERSECT
+// set operations for MySQL >= 8.0.31. For example:
+//
+//	Except(
+//		Dialect(dialect.MySQL).Select("id").From(Table("users")),
+//		Dialect(dialect.MySQL).Select("owner_id").From(Table("pets")),
+//		WithMySQLSetOp(),
+//	)
+//
+func WithMySQLSetOp() SetOpOption {
+	return func(o *SetOpOptions) {
+		o.MySQL = true
+	}
+}
+
+// Except returns a new Selector for the EXCEPT set operation between
+// the two given queries. Only ordering, limit and offset can be applied
+// on the returned Selector, and it fails on other clauses. For example:
+//
+//	Except(
+//		Select("id").From(Table("users")),
+//		Select("owner_id").From(Table("pets")),
+//	)
+//
+func Except(a, b *Selector, opts ...SetOpOption) *Selector {
+	return newSetOp("EXCEPT", a, b, opts)
+}
+
+// Intersect returns a new Selector for the INTERSECT set operation between
+// the two given queries. Only ordering, limit and offset can be applied
+// on the returned Selector, and it fails on other clauses. For example:
+//
+//	Intersect(
+//		Select("id").From(Table("users")),
+//		Select("owner_id").From(Table("pets")),
+//	)
+//
+func Intersect(a, b *Selector, opts ...SetOpOption) *Selector {
+	return newSetOp("INTERSECT", a, b, opts)
+}
+
+func newSetOp(op string, a, b *Selector, opts []SetOpOption) *Selector {
+	s := &Selector{setOp: &setOp{op: op, lhs: a, rhs: b}}
+	if a == nil || b == nil {
+		s.AddError(fmt.Errorf("sql: %s requires two non-nil queries", op))
+		return s
+	}
+	s.SetDialect(a.dialect)
+	var o SetOpOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if s.Dialect() == dialect.MySQL && !o.MySQL {
+		s.AddError(fmt.Errorf("sql: %s not supported in MySQL < 8.0.31. Use WithMySQLSetOp to enable it", op))
+	}
+	return s
+}
+
 // Prefix prefixes the query with list of queries.
 func (s *Selector) Prefix(queries ...Querier) *Selector {
 	s.prefix = append(s.prefix, queries...)
 	return s
 }
 
+// WithMaterialized prefixes the query with a MATERIALIZED CTE, or appends it to
+// the WITH clause in case the query is already prefixed with one. Postgres and
+// SQLite only.
+//
+//	Dialect(dialect.Postgres).
+//		Select("*").
+//		From(Table("active_users")).
+//		WithMaterialized("active_users", Select().From(Table("users")).Where(EQ("active", true)))
+//
+func (s *Selector) WithMaterialized(name string, sub *Selector) *Selector {
+	s.withCTE(name).AsMaterialized(sub)
+	return s
+}
+
+// WithNotMaterialized is like WithMaterialized, but marks the CTE as NOT MATERIALIZED.
+func (s *Selector) WithNotMaterialized(name string, sub *Selector) *Selector {
+	s.withCTE(name).AsNotMaterialized(sub)
+	return s
+}
+
+// withCTE appends a new named CTE to the WITH clause of the selector.
+func (s *Selector) withCTE(name string) *WithBuilder {
+	for _, q := range s.prefix {
+		if w, ok := q.(*WithBuilder); ok {
+			return w.With(name)
+		}
+	}
+	w := With(name)
+	s.Prefix(w)
+	return w
+}
+
 // C returns a formatted string for a selected column from this statement.
 func (s *Selector) C(column string) string {
 	if s.as != "" {
@@ -2571,21 +2871,24 @@ func (s *Selector) Clone() *Selector {
 		joins[i] = s.joins[i].clone()
 	}
 	return &Selector{
-		Builder:   s.Builder.clone(),
-		ctx:       s.ctx,
-		as:        s.as,
-		or:        s.or,
-		not:       s.not,
-		from:      s.from,
-		limit:     s.limit,
-		offset:    s.offset,
-		distinct:  s.distinct,
-		where:     s.where.clone(),
-		having:    s.having.clone(),
-		joins:     append([]join{}, joins...),
-		group:     append([]string{}, s.group...),
-		order:     append([]interface{}{}, s.order...),
-		selection: append([]interface{}{}, s.selection...),
+		Builder:    s.Builder.clone(),
+		ctx:        s.ctx,
+		as:         s.as,
+		or:         s.or,
+		not:        s.not,
+		from:       s.from,
+		limit:      s.limit,
+		offset:     s.offset,
+		distinct:   s.distinct,
+		distinctOn: append([]string(nil), s.distinctOn...),
+		comment:    s.comment,
+		setOp:      s.setOp,
+		where:      s.where.clone(),
+		having:     s.having.clone(),
+		joins:      append([]join{}, joins...),
+		group:      append([]string{}, s.group...),
+		order:      append([]interface{}{}, s.order...),
+		selection:  append([]interface{}{}, s.selection...),
 	}
 }
 
@@ -2644,16 +2947,80 @@ func (s *Selector) Having(p *Predicate) *Selector {
 	return s
 }
 
+// commentText neutralizes the sequences that open or terminate a comment, in order
+// to prevent the content of the comment from ending it or nesting another one (e.g.
+// in PostgreSQL). The replacement is repeated, as it may produce new sequences (/*/).
+func commentText(s string) string {
+	r := strings.NewReplacer("*/", "* /", "/*", "/ *")
+	for strings.Contains(s, "*/") || strings.Contains(s, "/*") {
+		s = r.Replace(s)
+	}
+	return s
+}
+
 // Query returns query representation of a `SELECT` statement.
 func (s *Selector) Query() (string, []interface{}) {
 	b := s.Builder.clone()
+	if s.comment != "" {
+		b.WriteString("/* ").WriteString(commentText(s.comment)).WriteString(" */ ")
+	}
 	s.joinPrefix(&b)
+	if s.setOp != nil {
+		s.joinSetOp(&b)
+	} else {
+		s.joinSelectFrom(&b)
+	}
+	if len(s.order) > 0 {
+		joinOrder(s.order, &b)
+	}
+	if s.limit != nil {
+		b.WriteString(" LIMIT ")
+		b.WriteString(strconv.Itoa(*s.limit))
+	}
+	if s.offset != nil {
+		b.WriteString(" OFFSET ")
+		b.WriteString(strconv.Itoa(*s.offset))
+	}
+	s.joinLock(&b)
+	s.total = b.total
+	s.AddError(b.Err())
+	return b.String(), b.args
+}
+
+// ToSQL is like Query, but also returns the errors that were encountered while building
+// the `SELECT` statement. The query is not executed, and therefore, it can be used for
+// inspecting the generated SQL and its arguments, for example, in unit tests.
+//
+//	query, args, err := Dialect(dialect.Postgres).
+//		Select("name").
+//		From(Table("users")).
+//		Where(EQ("age", 30)).
+//		ToSQL()
+//
+func (s *Selector) ToSQL() (string, []interface{}, error) {
+	query, args := s.Query()
+	if err := s.Err(); err != nil {
+		return "", nil, err
+	}
+	return query, args, nil
+}
+
+func (s *Selector) joinSelectFrom(b *Builder) {
 	b.WriteString("SELECT ")
-	if s.distinct {
+	switch {
+	case len(s.distinctOn) > 0 && !b.postgres():
+		b.AddError(fmt.Errorf("sql: SELECT DISTINCT ON not supported in %s", b.Dialect()))
+	case len(s.distinctOn) > 0:
+		b.WriteString("DISTINCT ON ")
+		b.Nested(func(b *Builder) {
+			b.IdentComma(s.distinctOn...)
+		})
+		b.WriteByte(' ')
+	case s.distinct:
 		b.WriteString("DISTINCT ")
 	}
 	if len(s.selection) > 0 {
-		s.joinSelect(&b)
+		s.joinSelect(b)
 	} else {
 		b.WriteString("*")
 	}
@@ -2674,6 +3041,9 @@ func (s *Selector) Query() (string, []interface{}) {
 		b.WriteString(" FROM ")
 		t.SetDialect(s.dialect)
 		b.Ident(t.Name())
+	case *valuesTable:
+		b.WriteString(" FROM ")
+		t.join(b, s.as)
 	}
 	for _, join := range s.joins {
 		b.WriteString(" " + join.kind + " ")
@@ -2710,23 +3080,33 @@ func (s *Selector) Query() (string, []interface{}) {
 		b.Join(s.having)
 	}
 	if len(s.union) > 0 {
-		s.joinUnion(&b)
+		s.joinUnion(b)
 	}
-	if len(s.order) > 0 {
-		joinOrder(s.order, &b)
+}
+
+func (s *Selector) joinSetOp(b *Builder) {
+	// Only ordering, limit and offset can be applied on the result of the set operation.
+	if s.from != nil || len(s.selection) > 0 || len(s.joins) > 0 || s.where != nil || len(s.group) > 0 ||
+		s.having != nil || s.distinct || len(s.distinctOn) > 0 || len(s.union) > 0 {
+		b.AddError(fmt.Errorf("sql: %s query does not support selection, joins, predicates, grouping or unions. Apply them on its operands, or select from it as a sub-query", s.setOp.op))
 	}
-	if s.limit != nil {
-		b.WriteString(" LIMIT ")
-		b.WriteString(strconv.Itoa(*s.limit))
+	// A missing operand was reported when the set operation was created.
+	if s.setOp.lhs == nil || s.setOp.rhs == nil {
+		return
 	}
-	if s.offset != nil {
-		b.WriteString(" OFFSET ")
-		b.WriteString(strconv.Itoa(*s.offset))
+	for i, q := range []*Selector{s.setOp.lhs, s.setOp.rhs} {
+		if i > 0 {
+			b.Pad().WriteString(s.setOp.op).Pad()
+		}
+		// SQLite does not accept parenthesized operands in compound statements.
+		if s.Dialect() == dialect.SQLite {
+			b.Join(q)
+			continue
+		}
+		b.Nested(func(b *Builder) {
+			b.Join(q)
+		})
 	}
-	s.joinLock(&b)
-	s.total = b.total
-	s.AddError(b.Err())
-	return b.String(), b.args
 }
 
 func (s *Selector) joinPrefix(b *Builder) {
@@ -2815,6 +3195,9 @@ type WithBuilder struct {
 		name    string
 		columns []string
 		s       *Selector
+		// materialized holds the optional MATERIALIZED
+		// or NOT MATERIALIZED keyword of the CTE.
+		materialized string
 	}
 }
 
@@ -2829,9 +3212,10 @@ type WithBuilder struct {
 func With(name string, columns ...string) *WithBuilder {
 	return &WithBuilder{
 		ctes: []struct {
-			name    string
-			columns []string
-			s       *Selector
+			name         string
+			columns      []string
+			s            *Selector
+			materialized string
 		}{
 			{name: name, columns: columns},
 		},
@@ -2863,6 +3247,23 @@ func (w *WithBuilder) As(s *Selector) *WithBuilder {
 	return w
 }
 
+// AsMaterialized sets the view sub query, and marks it as MATERIALIZED
+// for forcing the optimizer to compute it only once. Postgres and SQLite only.
+//
+//	With("users_view").AsMaterialized(Select().From(Table("users")))
+//
+func (w *WithBuilder) AsMaterialized(s *Selector) *WithBuilder {
+	w.ctes[len(w.ctes)-1].materialized = "MATERIALIZED"
+	return w.As(s)
+}
+
+// AsNotMaterialized sets the view sub query, and marks it as NOT MATERIALIZED
+// for allowing the optimizer to inline it in the parent query. Postgres and SQLite only.
+func (w *WithBuilder) AsNotMaterialized(s *Selector) *WithBuilder {
+	w.ctes[len(w.ctes)-1].materialized = "NOT MATERIALIZED"
+	return w.As(s)
+}
+
 // With appends another named CTE to the statement.
 func (w *WithBuilder) With(name string, columns ...string) *WithBuilder {
 	w.ctes = append(w.ctes, With(name, columns...).ctes...)
@@ -2893,6 +3294,12 @@ func (w *WithBuilder) Query() (string, []interface{}) {
 			w.WriteByte(')')
 		}
 		w.WriteString(" AS ")
+		if cte.materialized != "" {
+			if w.Dialect() == dialect.MySQL {
+				w.AddError(fmt.Errorf("sql: %s CTE not supported in MySQL", cte.materialized))
+			}
+			w.WriteString(cte.materialized + " ")
+		}
 		w.Nested(func(b *Builder) {
 			b.Join(cte.s)
 		})
@@ -3592,6 +3999,18 @@ func (d *DialectBuilder) Table(name string) *SelectTable {
 	return b
 }
 
+// ValuesTable creates a Selector on a `VALUES` clause for the configured dialect.
+//
+//	Dialect(dialect.Postgres).
+//		ValuesTable([][]driver.Value{{1, "a"}, {2, "b"}}, []string{"id", "name"}).
+//		As("t")
+//
+func (d *DialectBuilder) ValuesTable(rows [][]driver.Value, columns []string) *Selector {
+	b := ValuesTable(rows, columns)
+	b.SetDialect(d.dialect)
+	return b
+}
+
 // With creates a WithBuilder for the configured dialect.
 //
 //	Dialect(dialect.Postgres).
@@ -138,6 +138,28 @@ func TestBuilder(t *testing.T) {
 				),
 			wantQuery: `ALTER TABLE "users" ADD COLUMN "group_id" int UNIQUE, ADD CONSTRAINT FOREIGN KEY("group_id") REFERENCES "groups"("id")`,
 		},
+		{
+			input: Dialect(dialect.Postgres).AlterTable("nodes").
+				AddForeignKey(ForeignKey("nodes_sibling").Columns("sibling_id").
+					Reference(Reference().Table("nodes").Columns("id")).
+					OnDelete("SET NULL").
+					Deferrable(true),
+				),
+			wantQuery: `ALTER TABLE "nodes" ADD CONSTRAINT "nodes_sibling" FOREIGN KEY("sibling_id") REFERENCES "nodes"("id") ON DELETE SET NULL DEFERRABLE INITIALLY DEFERRED`,
+		},
+		{
+			input: Dialect(dialect.MySQL).AlterTable("nodes").
+				AddForeignKey(ForeignKey("nodes_sibling").Columns("sibling_id").
+					Reference(Reference().Table("nodes").Columns("id")).
+					Deferrable(false),
+				),
+			wantQuery: "ALTER TABLE `nodes` ADD CONSTRAINT `nodes_sibling` FOREIGN KEY(`sibling_id`) REFERENCES `nodes`(`id`)",
+		},
+		{
+			input: Dialect(dialect.Postgres).AlterTable("nodes").
+				AlterConstraint("nodes_sibling", "DEFERRABLE"),
+			wantQuery: `ALTER TABLE "nodes" ALTER CONSTRAINT "nodes_sibling" DEFERRABLE`,
+		},
 		{
 			input: AlterTable("users").
 				AddColumn(Column("age").Type("int")).
@@ -1408,6 +1430,19 @@ func TestBuilder(t *testing.T) {
 				Columns("first", "last"),
 			wantQuery: `CREATE UNIQUE INDEX "unique_name" ON "users"("first", "last")`,
 		},
+		{
+			input: Dialect(dialect.Postgres).
+				CreateIndex("name_index").
+				Concurrently().
+				IfNotExists().
+				Table("users").
+				Column("name"),
+			wantQuery: `CREATE INDEX CONCURRENTLY IF NOT EXISTS "name_index" ON "users"("name")`,
+		},
+		{
+			input:     CreateIndex("name_index").Concurrently().Table("users").Column("name"),
+			wantQuery: "CREATE INDEX `name_index` ON `users`(`name`)",
+		},
 		{
 			input:     DropIndex("name_index"),
 			wantQuery: "DROP INDEX `name_index`",
@@ -1602,6 +1637,26 @@ func TestBuilder_Err(t *testing.T) {
 	require.EqualError(t, b.Err(), "invalid; unexpected; inner")
 }
 
+func TestSelector_ToSQL(t *testing.T) {
+	query, args, err := Dialect(dialect.Postgres).
+		Select("name").
+		From(Table("users")).
+		Where(EQ("age", 30)).
+		ToSQL()
+	require.NoError(t, err)
+	require.Equal(t, `SELECT "name" FROM "users" WHERE "age" = $1`, query)
+	require.Equal(t, []interface{}{30}, args)
+
+	query, args, err = Dialect(dialect.MySQL).
+		Select("name").
+		SelectDistinctOn("owner_id").
+		From(Table("pets")).
+		ToSQL()
+	require.EqualError(t, err, "sql: SELECT DISTINCT ON not supported in mysql")
+	require.Empty(t, query)
+	require.Nil(t, args)
+}
+
 func TestSelector_OrderByExpr(t *testing.T) {
 	query, args := Select("*").
 		From(Table("users")).
@@ -1613,6 +1668,57 @@ func TestSelector_OrderByExpr(t *testing.T) {
 	require.Equal(t, []interface{}{28, 1, 2}, args)
 }
 
+func TestSelector_Comment(t *testing.T) {
+	query, args := Select("*").
+		From(Table("users")).
+		Where(EQ("active", true)).
+		Comment("user:list:active").
+		Query()
+	require.Equal(t, "/* user:list:active */ SELECT * FROM `users` WHERE `active`", query)
+	require.Equal(t, []interface{}(nil), args)
+
+	query, _ = Dialect(dialect.Postgres).
+		Select("id").
+		From(Table("users")).
+		Comment("a */ DROP TABLE users; /*").
+		Clone().
+		Query()
+	require.Equal(t, `/* a * / DROP TABLE users; / * */ SELECT "id" FROM "users"`, query)
+
+	query, _ = Select("*").
+		From(Table("users")).
+		Comment("/*/ nested").
+		Query()
+	require.Equal(t, "/* / * / nested */ SELECT * FROM `users`", query)
+}
+
+func TestSelector_SelectDistinctOn(t *testing.T) {
+	t1 := Table("pets")
+	query, args := Dialect(dialect.Postgres).
+		Select(t1.C("owner_id"), t1.C("name")).
+		SelectDistinctOn(t1.C("owner_id")).
+		From(t1).
+		Where(GT(t1.C("age"), 1)).
+		OrderBy(t1.C("owner_id"), Desc(t1.C("created_at"))).
+		Query()
+	require.Equal(t, `SELECT DISTINCT ON ("pets"."owner_id") "pets"."owner_id", "pets"."name" FROM "pets" WHERE "pets"."age" > $1 ORDER BY "pets"."owner_id", "pets"."created_at" DESC`, query)
+	require.Equal(t, []interface{}{1}, args)
+
+	query, _ = Dialect(dialect.Postgres).
+		Select("owner_id", "name").
+		SelectDistinctOn("owner_id", "name").
+		From(Table("pets")).
+		Clone().
+		Query()
+	require.Equal(t, `SELECT DISTINCT ON ("owner_id", "name") "owner_id", "name" FROM "pets"`, query)
+
+	for _, d := range []string{dialect.MySQL, dialect.SQLite} {
+		s := Dialect(d).Select("name").SelectDistinctOn("owner_id").From(Table("pets"))
+		s.Query()
+		require.EqualError(t, s.Err(), fmt.Sprintf("sql: SELECT DISTINCT ON not supported in %s", d))
+	}
+}
+
 func TestSelector_SelectExpr(t *testing.T) {
 	query, args := SelectExpr(
 		Expr("?", "a"),
@@ -1809,6 +1915,60 @@ func TestSelector_UnionOrderBy(t *testing.T) {
 	require.Equal(t, `SELECT * FROM "users" WHERE "active" UNION SELECT * FROM "old_users1" ORDER BY "users"."whatever"`, query)
 }
 
+func TestSelector_SetOp(t *testing.T) {
+	d := Dialect(dialect.Postgres)
+	query, args := Except(
+		d.Select("id").From(Table("users")).Where(EQ("active", true)),
+		d.Select("owner_id").From(Table("pets")).Where(GT("age", 10)),
+	).
+		OrderBy("id").
+		Limit(5).
+		Query()
+	require.Equal(t, `(SELECT "id" FROM "users" WHERE "active") EXCEPT (SELECT "owner_id" FROM "pets" WHERE "age" > $1) ORDER BY "id" LIMIT 5`, query)
+	require.Equal(t, []interface{}{10}, args)
+
+	d = Dialect(dialect.SQLite)
+	query, args = Intersect(
+		d.Select("id").From(Table("users")).Where(EQ("name", "a8m")),
+		d.Select("owner_id").From(Table("pets")),
+	).Query()
+	require.Equal(t, "SELECT `id` FROM `users` WHERE `name` = ? INTERSECT SELECT `owner_id` FROM `pets`", query)
+	require.Equal(t, []interface{}{"a8m"}, args)
+
+	d = Dialect(dialect.MySQL)
+	s := Intersect(d.Select("id").From(Table("users")), d.Select("owner_id").From(Table("pets")))
+	s.Query()
+	require.EqualError(t, s.Err(), "sql: INTERSECT not supported in MySQL < 8.0.31. Use WithMySQLSetOp to enable it")
+	s = Intersect(d.Select("id").From(Table("users")), d.Select("owner_id").From(Table("pets")), WithMySQLSetOp())
+	query, _ = s.Query()
+	require.NoError(t, s.Err())
+	require.Equal(t, "(SELECT `id` FROM `users`) INTERSECT (SELECT `owner_id` FROM `pets`)", query)
+
+	for _, s := range []*Selector{
+		Except(nil, d.Select("id").From(Table("users"))),
+		Except(d.Select("id").From(Table("users")), nil),
+	} {
+		s.Query()
+		require.EqualError(t, s.Err(), "sql: EXCEPT requires two non-nil queries")
+	}
+
+	d = Dialect(dialect.Postgres)
+	for _, s := range []*Selector{
+		Except(d.Select("id").From(Table("users")), d.Select("owner_id").From(Table("pets"))).Where(EQ("id", 1)),
+		Except(d.Select("id").From(Table("users")), d.Select("owner_id").From(Table("pets"))).Join(Table("groups")),
+		Except(d.Select("id").From(Table("users")), d.Select("owner_id").From(Table("pets"))).Select("id"),
+		Except(d.Select("id").From(Table("users")), d.Select("owner_id").From(Table("pets"))).Union(d.Select("id").From(Table("groups"))),
+	} {
+		s.Query()
+		require.EqualError(t, s.Err(), "sql: EXCEPT query does not support selection, joins, predicates, grouping or unions. Apply them on its operands, or select from it as a sub-query")
+	}
+	// Set operations can be filtered as sub-queries.
+	except := Except(d.Select("id").From(Table("users")), d.Select("owner_id").From(Table("pets"))).As("t")
+	query, args = d.Select(except.C("id")).From(except).Where(GT(except.C("id"), 1)).Query()
+	require.Equal(t, `SELECT "t"."id" FROM ((SELECT "id" FROM "users") EXCEPT (SELECT "owner_id" FROM "pets")) AS "t" WHERE "t"."id" > $1`, query)
+	require.Equal(t, []interface{}{1}, args)
+}
+
 func TestUpdateBuilder_SetExpr(t *testing.T) {
 	d := Dialect(dialect.Postgres)
 	excluded := d.Table("excluded")
@@ -2085,6 +2245,28 @@ func TestWindowFunction(t *testing.T) {
 	require.Equal(t, []interface{}{2}, args)
 }
 
+func TestSelector_WithMaterialized(t *testing.T) {
+	d := Dialect(dialect.Postgres)
+	query, args := d.Select("*").
+		From(Table("active_users")).
+		WithMaterialized("active_users", Select().From(Table("users")).Where(EQ("active", true))).
+		WithNotMaterialized("pets_view", Select().From(Table("pets")).Where(EQ("owner_id", 1))).
+		Query()
+	require.Equal(t, `WITH "active_users" AS MATERIALIZED (SELECT * FROM "users" WHERE "active"), "pets_view" AS NOT MATERIALIZED (SELECT * FROM "pets" WHERE "owner_id" = $1) SELECT * FROM "active_users"`, query)
+	require.Equal(t, []interface{}{1}, args)
+
+	query, _ = d.Select("*").
+		From(Table("users_view")).
+		Prefix(With("users_view").As(Select().From(Table("users")))).
+		WithMaterialized("pets_view", Select().From(Table("pets"))).
+		Query()
+	require.Equal(t, `WITH "users_view" AS (SELECT * FROM "users"), "pets_view" AS MATERIALIZED (SELECT * FROM "pets") SELECT * FROM "users_view"`, query)
+
+	s := Dialect(dialect.MySQL).Select("*").From(Table("users_view")).WithMaterialized("users_view", Select().From(Table("users")))
+	s.Query()
+	require.EqualError(t, s.Err(), "sql: MATERIALIZED CTE not supported in MySQL")
+}
+
 func TestSelector_UnqualifiedColumns(t *testing.T) {
 	t1, t2 := Table("t1"), Table("t2")
 	s := Select(t1.C("a"), t2.C("b"))
@@ -2097,3 +2279,57 @@ func TestSelector_UnqualifiedColumns(t *testing.T) {
 	require.Equal(t, []string{`"t1"."a"`, `"t2"."b"`}, s.SelectedColumns())
 	require.Equal(t, []string{"a", "b"}, s.UnqualifiedColumns())
 }
+
+func TestSelector_ValuesTable(t *testing.T) {
+	rows := [][]driver.Value{{1, "a"}, {2, "b"}}
+	query, args := Dialect(dialect.Postgres).ValuesTable(rows, []string{"id", "name"}).Query()
+	require.Equal(t, `SELECT * FROM (VALUES ($1, $2), ($3, $4)) AS "t"("id", "name")`, query)
+	require.Equal(t, []interface{}{1, "a", 2, "b"}, args)
+
+	d := Dialect(dialect.Postgres)
+	t1, t2 := d.Table("users"), d.ValuesTable(rows, []string{"id", "name"}).As("v")
+	query, args = d.Select(t1.C("name"), t2.C("name")).
+		From(t1).
+		Join(t2).
+		On(t1.C("id"), t2.C("id")).
+		Where(GT(t1.C("age"), 30)).
+		Query()
+	require.Equal(t, `SELECT "users"."name", "v"."name" FROM "users" JOIN (SELECT * FROM (VALUES ($1, $2), ($3, $4)) AS "v"("id", "name")) AS "v" ON "users"."id" = "v"."id" WHERE "users"."age" > $5`, query)
+	require.Equal(t, []interface{}{1, "a", 2, "b", 30}, args)
+
+	query, args = Dialect(dialect.MySQL).ValuesTable(rows, []string{"id", "name"}).Query()
+	require.Equal(t, "SELECT * FROM (VALUES ROW(?, ?), ROW(?, ?)) AS `t`(`id`, `name`)", query)
+	require.Equal(t, []interface{}{1, "a", 2, "b"}, args)
+
+	query, args = Dialect(dialect.SQLite).ValuesTable(rows, []string{"id", "name"}).Query()
+	require.Equal(t, "SELECT * FROM (SELECT ? AS `id`, ? AS `name` UNION ALL SELECT ?, ?) AS `t`", query)
+	require.Equal(t, []interface{}{1, "a", 2, "b"}, args)
+
+	err := ValuesTable([][]driver.Value{{1}}, []string{"id", "name"}).Err()
+	require.EqualError(t, err, "sql: row 0 of VALUES table has 1 values, but 2 columns were given")
+}
+
+func TestSelector_ValuesLazy(t *testing.T) {
+	var calls int
+	s := Dialect(dialect.Postgres).Select().ValuesLazy(func() ([][]driver.Value, []string) {
+		calls++
+		return [][]driver.Value{{1, "a"}, {2, "b"}}, []string{"id", "name"}
+	})
+	require.Zero(t, calls)
+	query, args := s.Query()
+	require.Equal(t, 1, calls)
+	require.Equal(t, `SELECT * FROM (VALUES ($1, $2), ($3, $4)) AS "t"("id", "name")`, query)
+	require.Equal(t, []interface{}{1, "a", 2, "b"}, args)
+
+	query, args = Dialect(dialect.SQLite).Select().ValuesLazy(func() ([][]driver.Value, []string) {
+		return [][]driver.Value{{1, "a"}}, []string{"id", "name"}
+	}).As("v").Query()
+	require.Equal(t, "SELECT * FROM (SELECT ? AS `id`, ? AS `name`) AS `v`", query)
+	require.Equal(t, []interface{}{1, "a"}, args)
+
+	s = Select().ValuesLazy(func() ([][]driver.Value, []string) {
+		return nil, []string{"id"}
+	})
+	s.Query()
+	require.EqualError(t, s.Err(), "sql: missing rows for VALUES table")
+}
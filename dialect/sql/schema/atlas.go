@@ -242,6 +242,20 @@ func WithApplyHook(hooks ...ApplyHook) MigrateOption {
 	}
 }
 
+// WithDiffChanges appends the given changes (e.g. data statements) to the migration plans
+// that are written by Diff and NamedDiff, if the plans contain schema changes.
+//
+//	schema.WithDiffChanges(&migrate.Change{
+//		Cmd:     "INSERT INTO settings (name) VALUES ('default')",
+//		Comment: "add default settings",
+//	})
+//
+func WithDiffChanges(changes ...*migrate.Change) MigrateOption {
+	return func(m *Migrate) {
+		m.atlas.changes = append(m.atlas.changes, changes...)
+	}
+}
+
 // WithAtlas is an opt-in option for v0.10 indicates the migration
 // should be executed using Atlas engine (i.e. https://atlasgo.io).
 // Note, in future versions, this option is going to be replaced
@@ -294,6 +308,7 @@ type (
 		enabled          bool
 		diff             []DiffHook
 		apply            []ApplyHook
+		changes          []*migrate.Change
 		skip             ChangeKind
 		dir              migrate.Dir
 		fmt              migrate.Formatter
@@ -367,6 +382,22 @@ func (m *Migrate) atCreate(ctx context.Context, tables ...*Table) error {
 	if err != nil {
 		return err
 	}
+	// Apply plan (changes).
+	var applier Applier = ApplyFunc(func(ctx context.Context, tx dialect.ExecQuerier, plan *migrate.Plan) error {
+		for _, c := range plan.Changes {
+			if err := tx.Exec(ctx, c.Cmd, c.Args, nil); err != nil {
+				if c.Comment != "" {
+					err = fmt.Errorf("%s: %w", c.Comment, err)
+				}
+				return err
+			}
+		}
+		return nil
+	})
+	for i := len(m.atlas.apply) - 1; i >= 0; i-- {
+		applier = m.atlas.apply[i](applier)
+	}
+	var concurrent *migrate.Plan
 	if err := func() error {
 		if err := m.init(ctx, tx); err != nil {
 			return err
@@ -376,33 +407,36 @@ func (m *Migrate) atCreate(ctx context.Context, tables ...*Table) error {
 				return err
 			}
 		}
+		backfills, err := m.pendingBackfills(ctx, tx)
+		if err != nil {
+			return err
+		}
 		plan, err := m.atDiff(ctx, tx, "", tables...)
 		if err != nil {
 			return err
 		}
-		// Apply plan (changes).
-		var applier Applier = ApplyFunc(func(ctx context.Context, tx dialect.ExecQuerier, plan *migrate.Plan) error {
-			for _, c := range plan.Changes {
-				if err := tx.Exec(ctx, c.Cmd, c.Args, nil); err != nil {
-					if c.Comment != "" {
-						err = fmt.Errorf("%s: %w", c.Comment, err)
-					}
-					return err
-				}
-			}
-			return nil
-		})
-		for i := len(m.atlas.apply) - 1; i >= 0; i-- {
-			applier = m.atlas.apply[i](applier)
+		// Concurrent indexes are created after the transaction is committed.
+		plan, concurrent = atSplitConcurrent(plan)
+		if err := applier.Apply(ctx, tx, plan); err != nil {
+			return err
 		}
-		return applier.Apply(ctx, tx, plan)
+		return runBackfills(ctx, tx, backfills)
 	}(); err != nil {
 		return rollback(tx, err)
 	}
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if len(concurrent.Changes) == 0 {
+		return nil
+	}
+	return applier.Apply(ctx, m, concurrent)
 }
 
 func (m *Migrate) atDiff(ctx context.Context, conn dialect.ExecQuerier, name string, tables ...*Table) (*migrate.Plan, error) {
+	if err := checkStorage(m.Dialect(), tables); err != nil {
+		return nil, err
+	}
 	drv, err := m.atOpen(conn)
 	if err != nil {
 		return nil, err
@@ -431,8 +465,235 @@ func (m *Migrate) atDiff(ctx context.Context, conn dialect.ExecQuerier, name str
 	if err != nil {
 		return nil, err
 	}
+	var concurrent []*schema.AddIndex
+	if m.Dialect() == dialect.Postgres {
+		concurrent = atConcurrent(changes, tables)
+	}
 	// Plan changes.
-	return drv.PlanChanges(ctx, name, changes)
+	plan, err := drv.PlanChanges(ctx, name, changes)
+	if err != nil {
+		return nil, err
+	}
+	if err := atPlanConcurrent(ctx, drv, plan, concurrent); err != nil {
+		return nil, err
+	}
+	if s, ok := m.sqlDialect.(storageSetter); ok {
+		atStorage(s, plan, changes, tables)
+	}
+	if d, ok := m.sqlDialect.(constraintDeferrer); ok {
+		atDeferrable(d, plan, changes, tables)
+	}
+	if u, ok := m.sqlDialect.(unloggedSetter); ok {
+		atUnlogged(u, plan, changes, tables)
+	}
+	return plan, nil
+}
+
+// atStorage appends the statements for setting the storage mode of new
+// and modified columns to the plan, as it is not supported by Atlas.
+func atStorage(s storageSetter, plan *migrate.Plan, changes []schema.Change, tables []*Table) {
+	byName := make(map[string]*Table, len(tables))
+	for _, t := range tables {
+		byName[t.Name] = t
+	}
+	for _, c := range changes {
+		var (
+			t       *Table
+			changed []*schema.Column
+		)
+		switch c := c.(type) {
+		case *schema.AddTable:
+			t, changed = byName[c.T.Name], c.T.Columns
+		case *schema.ModifyTable:
+			t = byName[c.T.Name]
+			for _, c := range c.Changes {
+				switch c := c.(type) {
+				case *schema.AddColumn:
+					changed = append(changed, c.C)
+				case *schema.ModifyColumn:
+					changed = append(changed, c.To)
+				}
+			}
+		}
+		if t == nil {
+			continue
+		}
+		columns := make([]*Column, 0, len(changed))
+		for _, c2 := range changed {
+			if c1, ok := t.column(c2.Name); ok {
+				columns = append(columns, c1)
+			}
+		}
+		if q := s.setStorage(t.Name, columns); q != nil {
+			cmd, args := q.Query()
+			plan.Changes = append(plan.Changes, &migrate.Change{
+				Cmd:     cmd,
+				Args:    args,
+				Comment: fmt.Sprintf("set columns storage of table %q", t.Name),
+			})
+		}
+	}
+}
+
+// atDeferrable appends the statements for making new foreign-keys
+// deferrable to the plan, as it is not supported by Atlas.
+func atDeferrable(d constraintDeferrer, plan *migrate.Plan, changes []schema.Change, tables []*Table) {
+	byName := make(map[string]*Table, len(tables))
+	for _, t := range tables {
+		byName[t.Name] = t
+	}
+	for _, c := range changes {
+		var (
+			t     *Table
+			added []*schema.ForeignKey
+		)
+		switch c := c.(type) {
+		case *schema.AddTable:
+			t, added = byName[c.T.Name], c.T.ForeignKeys
+		case *schema.ModifyTable:
+			t = byName[c.T.Name]
+			for _, c := range c.Changes {
+				if c, ok := c.(*schema.AddForeignKey); ok {
+					added = append(added, c.F)
+				}
+			}
+		}
+		if t == nil {
+			continue
+		}
+		fks := make([]*ForeignKey, 0, len(added))
+		for _, fk2 := range added {
+			for _, fk1 := range t.ForeignKeys {
+				if fk1.Symbol == fk2.Symbol {
+					fks = append(fks, fk1)
+				}
+			}
+		}
+		if q := d.deferConstraints(t.Name, fks); q != nil {
+			cmd, args := q.Query()
+			plan.Changes = append(plan.Changes, &migrate.Change{
+				Cmd:     cmd,
+				Args:    args,
+				Comment: fmt.Sprintf("set deferrable constraints of table %q", t.Name),
+			})
+		}
+	}
+}
+
+// atUnlogged appends the statements for making new tables
+// unlogged to the plan, as it is not supported by Atlas.
+func atUnlogged(u unloggedSetter, plan *migrate.Plan, changes []schema.Change, tables []*Table) {
+	byName := make(map[string]*Table, len(tables))
+	for _, t := range tables {
+		byName[t.Name] = t
+	}
+	for _, c := range changes {
+		c, ok := c.(*schema.AddTable)
+		if !ok {
+			continue
+		}
+		t, ok := byName[c.T.Name]
+		if !ok {
+			continue
+		}
+		if q := u.setUnlogged(t); q != nil {
+			cmd, args := q.Query()
+			plan.Changes = append(plan.Changes, &migrate.Change{
+				Cmd:     cmd,
+				Args:    args,
+				Comment: fmt.Sprintf("set table %q unlogged", t.Name),
+			})
+		}
+	}
+}
+
+// concurrentIndex wraps the AddIndex change of
+// indexes that are created using CONCURRENTLY.
+type concurrentIndex struct {
+	*schema.AddIndex
+}
+
+// atConcurrent removes the concurrent indexes from the given changes
+// and returns them separately, because Postgres does not allow creating
+// indexes concurrently in a transaction block.
+func atConcurrent(changes []schema.Change, tables []*Table) []*schema.AddIndex {
+	byName := make(map[string]*Table, len(tables))
+	for _, t := range tables {
+		byName[t.Name] = t
+	}
+	isConcurrent := func(t *schema.Table, name string) bool {
+		t1, ok := byName[t.Name]
+		if !ok {
+			return false
+		}
+		idx, ok := t1.Index(name)
+		return ok && idx.Concurrent
+	}
+	var concurrent []*schema.AddIndex
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddTable:
+			indexes := make([]*schema.Index, 0, len(c.T.Indexes))
+			for _, idx := range c.T.Indexes {
+				if isConcurrent(c.T, idx.Name) {
+					concurrent = append(concurrent, &schema.AddIndex{I: idx})
+				} else {
+					indexes = append(indexes, idx)
+				}
+			}
+			c.T.Indexes = indexes
+		case *schema.ModifyTable:
+			modify := make([]schema.Change, 0, len(c.Changes))
+			for _, c1 := range c.Changes {
+				if add, ok := c1.(*schema.AddIndex); ok && isConcurrent(c.T, add.I.Name) {
+					concurrent = append(concurrent, add)
+				} else {
+					modify = append(modify, c1)
+				}
+			}
+			c.Changes = modify
+		}
+	}
+	return concurrent
+}
+
+// atPlanConcurrent appends the statements for creating the given indexes
+// concurrently to the plan, and marks the plan as non-transactional.
+func atPlanConcurrent(ctx context.Context, drv migrate.Driver, plan *migrate.Plan, indexes []*schema.AddIndex) error {
+	for _, add := range indexes {
+		p, err := drv.PlanChanges(ctx, plan.Name, []schema.Change{
+			&schema.ModifyTable{T: add.I.Table, Changes: []schema.Change{add}},
+		})
+		if err != nil {
+			return err
+		}
+		for _, c := range p.Changes {
+			plan.Changes = append(plan.Changes, &migrate.Change{
+				Cmd:     strings.Replace(c.Cmd, "INDEX ", "INDEX CONCURRENTLY ", 1),
+				Args:    c.Args,
+				Comment: c.Comment,
+				Reverse: c.Reverse,
+				Source:  &concurrentIndex{AddIndex: add},
+			})
+		}
+		plan.Transactional = false
+	}
+	return nil
+}
+
+// atSplitConcurrent splits the given plan into two plans. The first holds the changes that are
+// executed in the migration transaction, and the second holds the concurrent index creations.
+func atSplitConcurrent(plan *migrate.Plan) (*migrate.Plan, *migrate.Plan) {
+	tx, concurrent := *plan, *plan
+	tx.Changes, concurrent.Changes = nil, nil
+	for _, c := range plan.Changes {
+		if _, ok := c.Source.(*concurrentIndex); ok {
+			concurrent.Changes = append(concurrent.Changes, c)
+		} else {
+			tx.Changes = append(tx.Changes, c)
+		}
+	}
+	return &tx, &concurrent
 }
 
 type db struct{ dialect.ExecQuerier }
@@ -556,6 +817,10 @@ func (m *Migrate) aIndexes(b atBuilder, t1 *Table, t2 *schema.Table) error {
 	for _, idx1 := range t1.Indexes {
 		idx2 := schema.NewIndex(idx1.Name).
 			SetUnique(idx1.Unique)
+		if idx1.Expr != "" {
+			t2.AddIndexes(idx2.AddParts(&schema.IndexPart{X: &schema.RawExpr{X: idx1.Expr}}))
+			continue
+		}
 		if err := b.atIndex(idx1, t2, idx2); err != nil {
 			return err
 		}
@@ -622,18 +887,21 @@ func setAtChecks(t1 *Table, t2 *schema.Table) {
 			Expr: check,
 		})
 	}
-	if checks := t1.Annotation.Checks; len(t1.Annotation.Checks) > 0 {
-		names := make([]string, 0, len(checks))
-		for name := range checks {
-			names = append(names, name)
-		}
-		sort.Strings(names)
-		for _, name := range names {
-			t2.AddChecks(&schema.Check{
-				Name: name,
-				Expr: checks[name],
-			})
-		}
+	setAtNamedChecks(t2, t1.Annotation.Checks)
+}
+
+// setAtNamedChecks adds the given named checks to the table sorted by their names.
+func setAtNamedChecks(t *schema.Table, checks map[string]string) {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t.AddChecks(&schema.Check{
+			Name: name,
+			Expr: checks[name],
+		})
 	}
 }
 
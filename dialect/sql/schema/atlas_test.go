@@ -10,7 +10,12 @@ import (
 	"path/filepath"
 	"testing"
 
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/field"
+
 	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,3 +36,136 @@ func TestDirTypeStore(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, ex, ac)
 }
+
+func TestAtConcurrent(t *testing.T) {
+	users := &Table{Name: "users", Indexes: Indexes{{Name: "email", Concurrent: true}, {Name: "name"}}}
+	t2 := schema.NewTable("users").
+		AddIndexes(schema.NewIndex("email"), schema.NewIndex("name"))
+	changes := []schema.Change{
+		&schema.AddTable{T: t2},
+		&schema.ModifyTable{
+			T: schema.NewTable("pets"),
+			Changes: []schema.Change{
+				&schema.AddIndex{I: schema.NewIndex("owner")},
+			},
+		},
+	}
+	concurrent := atConcurrent(changes, []*Table{users, {Name: "pets"}})
+	require.Len(t, concurrent, 1)
+	require.Equal(t, "email", concurrent[0].I.Name)
+	require.Len(t, t2.Indexes, 1)
+	require.Equal(t, "name", t2.Indexes[0].Name)
+	require.Len(t, changes[1].(*schema.ModifyTable).Changes, 1)
+
+	plan := &migrate.Plan{Changes: []*migrate.Change{{Cmd: "CREATE TABLE"}, {Cmd: "CREATE INDEX", Source: &concurrentIndex{AddIndex: concurrent[0]}}}}
+	tx, idx := atSplitConcurrent(plan)
+	require.Len(t, tx.Changes, 1)
+	require.Equal(t, "CREATE TABLE", tx.Changes[0].Cmd)
+	require.Len(t, idx.Changes, 1)
+	require.Equal(t, "CREATE INDEX", idx.Changes[0].Cmd)
+}
+
+func TestAtDeferrable(t *testing.T) {
+	nodes := &Table{Name: "nodes"}
+	nodes.AddForeignKey(&ForeignKey{Symbol: "nodes_parent", InitiallyDeferred: true, Deferrable: true})
+	nodes.AddForeignKey(&ForeignKey{Symbol: "nodes_sibling", Deferrable: true})
+	nodes.AddForeignKey(&ForeignKey{Symbol: "nodes_owner"})
+	changes := []schema.Change{
+		&schema.ModifyTable{
+			T: schema.NewTable("nodes"),
+			Changes: []schema.Change{
+				&schema.AddForeignKey{F: schema.NewForeignKey("nodes_parent")},
+				&schema.AddForeignKey{F: schema.NewForeignKey("nodes_owner")},
+			},
+		},
+		&schema.AddTable{T: schema.NewTable("users")},
+	}
+	plan := &migrate.Plan{}
+	atDeferrable(&Postgres{}, plan, changes, []*Table{nodes, {Name: "users"}})
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `ALTER TABLE "nodes" ALTER CONSTRAINT "nodes_parent" DEFERRABLE INITIALLY DEFERRED`, plan.Changes[0].Cmd)
+}
+
+func TestAtStorage(t *testing.T) {
+	posts := &Table{
+		Name: "posts",
+		Columns: []*Column{
+			{Name: "title", Type: field.TypeString},
+			{Name: "body", Type: field.TypeString, Storage: "extended"},
+			{Name: "data", Type: field.TypeBytes, Storage: "EXTERNAL"},
+		},
+	}
+	users := &Table{
+		Name: "users",
+		Columns: []*Column{
+			{Name: "name", Type: field.TypeString},
+			{Name: "avatar", Type: field.TypeBytes, Storage: "EXTERNAL"},
+			{Name: "bio", Type: field.TypeString, Storage: "MAIN"},
+		},
+	}
+	changes := []schema.Change{
+		&schema.AddTable{T: schema.NewTable("posts").AddColumns(schema.NewColumn("title"), schema.NewColumn("body"), schema.NewColumn("data"))},
+		&schema.ModifyTable{
+			T: schema.NewTable("users"),
+			Changes: []schema.Change{
+				&schema.AddColumn{C: schema.NewColumn("avatar")},
+				&schema.ModifyColumn{From: schema.NewColumn("bio"), To: schema.NewColumn("bio")},
+				&schema.ModifyColumn{From: schema.NewColumn("name"), To: schema.NewColumn("name")},
+			},
+		},
+		&schema.ModifyTable{T: schema.NewTable("pets")},
+	}
+	plan := &migrate.Plan{}
+	atStorage(&Postgres{}, plan, changes, []*Table{posts, users, NewTable("pets")})
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `ALTER TABLE "posts" ALTER COLUMN "body" SET STORAGE EXTENDED, ALTER COLUMN "data" SET STORAGE EXTERNAL`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE "users" ALTER COLUMN "avatar" SET STORAGE EXTERNAL, ALTER COLUMN "bio" SET STORAGE MAIN`, plan.Changes[1].Cmd)
+}
+
+func TestAtWordsChecks(t *testing.T) {
+	posts := &Table{
+		Name: "posts",
+		Columns: []*Column{
+			{Name: "title", Type: field.TypeString},
+			{Name: "excerpt", Type: field.TypeString, MaxWords: 50},
+		},
+	}
+	checks := func(d interface {
+		atTable(*Table, *schema.Table)
+	}) []*schema.Check {
+		var checks []*schema.Check
+		t2 := schema.NewTable("posts")
+		d.atTable(posts, t2)
+		for _, a := range t2.Attrs {
+			if c, ok := a.(*schema.Check); ok {
+				checks = append(checks, c)
+			}
+		}
+		return checks
+	}
+	require.Equal(t, []*schema.Check{{Name: "posts_excerpt_words", Expr: `CHAR_LENGTH(TRIM(REGEXP_REPLACE("excerpt", '[[:space:]]+', ' ', 'g'))) - CHAR_LENGTH(REPLACE(TRIM(REGEXP_REPLACE("excerpt", '[[:space:]]+', ' ', 'g')), ' ', '')) < 50`}}, checks(&Postgres{}))
+	require.Equal(t, []*schema.Check{{Name: "posts_excerpt_words", Expr: "CHAR_LENGTH(TRIM(REGEXP_REPLACE(`excerpt`, '[[:space:]]+', ' '))) - CHAR_LENGTH(REPLACE(TRIM(REGEXP_REPLACE(`excerpt`, '[[:space:]]+', ' ')), ' ', '')) < 50"}}, checks(&MySQL{version: "8.0.19"}))
+	require.Empty(t, checks(&MySQL{version: "5.7.23"}), "CHECK is not supported")
+	require.Empty(t, checks(&SQLite{}), "REGEXP_REPLACE is not supported")
+}
+
+func TestCheckStorage(t *testing.T) {
+	tables := []*Table{{Name: "posts", Columns: []*Column{{Name: "body", Type: field.TypeString, Storage: "Extended"}}}}
+	require.NoError(t, checkStorage(dialect.Postgres, tables))
+	require.EqualError(t, checkStorage(dialect.SQLite, tables), `sql/schema: storage mode of column "posts"."body" is supported only by PostgreSQL`)
+	tables[0].Columns[0].Storage = "COMPRESSED"
+	require.EqualError(t, checkStorage(dialect.Postgres, tables), `sql/schema: invalid storage mode "COMPRESSED" for column "posts"."body". Expect PLAIN, MAIN, EXTERNAL or EXTENDED`)
+}
+
+func TestAtUnlogged(t *testing.T) {
+	users := NewTable("users_unlogged").SetAnnotation(&entsql.Annotation{Unlogged: true})
+	changes := []schema.Change{
+		&schema.ModifyTable{T: schema.NewTable("pets")},
+		&schema.AddTable{T: schema.NewTable("users_unlogged")},
+		&schema.AddTable{T: schema.NewTable("users")},
+	}
+	plan := &migrate.Plan{}
+	atUnlogged(&Postgres{}, plan, changes, []*Table{users, NewTable("users"), NewTable("pets")})
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `ALTER TABLE "users_unlogged" SET UNLOGGED`, plan.Changes[0].Cmd)
+}
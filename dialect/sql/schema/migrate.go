@@ -75,6 +75,37 @@ func WithHooks(hooks ...Hook) MigrateOption {
 	}
 }
 
+// WithBackfill registers a function for backfilling the given column after it was added
+// to the table. The function is executed in the migration transaction after the schema
+// changes were applied, and only if the column did not exist before the migration.
+func WithBackfill(table, column string, fn BackfillFunc) MigrateOption {
+	return func(m *Migrate) {
+		m.backfills = append(m.backfills, &backfill{table: table, column: column, fn: fn})
+	}
+}
+
+// WithLogger sets the logger of the migration, which is used for reporting options that
+// are ignored by the dialect (e.g. concurrent indexes in MySQL). Defaults to no logging.
+//
+//	schema.WithLogger(log.Println)
+//
+func WithLogger(logger func(...interface{})) MigrateOption {
+	return func(m *Migrate) {
+		m.logger = logger
+	}
+}
+
+// BackfillFunc is the function that backfills new columns in the migration transaction.
+// Note that, in dialects that do not support transactional DDL (e.g. MySQL), the schema
+// changes are committed implicitly before the function is executed.
+type BackfillFunc func(context.Context, dialect.Tx) error
+
+// backfill holds the backfill function of a column.
+type backfill struct {
+	table, column string
+	fn            BackfillFunc
+}
+
 type (
 	// Creator is the interface that wraps the Create method.
 	Creator interface {
@@ -107,17 +138,19 @@ func (f CreateFunc) Create(ctx context.Context, tables ...*Table) error {
 // Migrate runs the migration logic for the SQL dialects.
 type Migrate struct {
 	sqlDialect
-	universalID     bool          // global unique ids.
-	dropColumns     bool          // drop deleted columns.
-	dropIndexes     bool          // drop deleted indexes.
-	withFixture     bool          // with fks rename fixture.
-	withForeignKeys bool          // with foreign keys
-	atlas           *atlasOptions // migrate with atlas.
-	typeRanges      []string      // types order by their range.
-	hooks           []Hook        // hooks to apply before creation
-	typeStore       typeStore     // the typeStore to read and save type ranges
-	fileTypeRanges  []string      // used internally by ensureTypeTable hook
-	dbTypeRanges    []string      // used internally by ensureTypeTable hook
+	universalID     bool                 // global unique ids.
+	dropColumns     bool                 // drop deleted columns.
+	dropIndexes     bool                 // drop deleted indexes.
+	withFixture     bool                 // with fks rename fixture.
+	withForeignKeys bool                 // with foreign keys
+	atlas           *atlasOptions        // migrate with atlas.
+	typeRanges      []string             // types order by their range.
+	hooks           []Hook               // hooks to apply before creation
+	backfills       []*backfill          // column backfills to run after creation
+	logger          func(...interface{}) // logger for ignored options
+	typeStore       typeStore            // the typeStore to read and save type ranges
+	fileTypeRanges  []string             // used internally by ensureTypeTable hook
+	dbTypeRanges    []string             // used internally by ensureTypeTable hook
 }
 
 // NewMigrate create a migration structure for the given SQL driver.
@@ -154,6 +187,15 @@ func NewMigrate(d dialect.Driver, opts ...MigrateOption) (*Migrate, error) {
 // since it's used only for testing.
 func (m *Migrate) Create(ctx context.Context, tables ...*Table) error {
 	m.setupTables(tables)
+	if m.Dialect() != dialect.Postgres {
+		for _, t := range tables {
+			for _, idx := range t.Indexes {
+				if idx.Concurrent && m.logger != nil {
+					m.logger(fmt.Sprintf("sql/schema: concurrent index %q of table %q is supported only by Postgres. Creating it regularly", idx.Name, t.Name))
+				}
+			}
+		}
+	}
 	var creator Creator = CreateFunc(m.create)
 	if m.atlas.enabled {
 		creator = CreateFunc(m.atCreate)
@@ -228,10 +270,71 @@ func (m *Migrate) NamedDiff(ctx context.Context, name string, tables ...*Table)
 	if len(plan.Changes) == 0 {
 		return nil
 	}
+	plan.Changes = append(plan.Changes, m.atlas.changes...)
 	return migrate.NewPlanner(nil, m.atlas.dir, opts...).WritePlan(plan)
 }
 
+// DryRun computes the migration plan of the given tables against the connected database, and returns
+// its statements without executing them. For example, for printing the planned changes in CI before
+// they are applied. Note that DryRun is supported only by Atlas migrations.
+//
+//	stmts, err := m.DryRun(ctx, migrate.Tables...)
+//	if err != nil {
+//		log.Fatalf("failed computing migration plan: %v", err)
+//	}
+//	for _, s := range stmts {
+//		fmt.Println(s)
+//	}
+//
+func (m *Migrate) DryRun(ctx context.Context, tables ...*Table) ([]string, error) {
+	if !m.atlas.enabled {
+		return nil, errors.New("sql/schema: DryRun is supported only in Atlas migration. Use WithAtlas(true)")
+	}
+	if m.universalID && m.atlas.dir != nil {
+		return nil, errors.New("sql/schema: DryRun does not support universal ids with a migration directory. Use Diff instead")
+	}
+	m.setupTables(tables)
+	if err := m.init(ctx, m); err != nil {
+		return nil, err
+	}
+	var (
+		stmts []string
+		types int
+	)
+	if m.universalID {
+		// Record the changes of the type store instead of executing them.
+		store := &dryTypeStore{dbTypeStore: &dbTypeStore{m.sqlDialect}}
+		defer func(s typeStore) { m.typeStore = s }(m.typeStore)
+		m.typeStore = store
+		if err := m.types(ctx, m); err != nil {
+			return nil, err
+		}
+		stmts, types = store.stmts, len(m.typeRanges)
+	}
+	plan, err := m.atDiff(ctx, m, "", tables...)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range plan.Changes {
+		stmts = append(stmts, c.Cmd)
+	}
+	if m.universalID && len(m.typeRanges) > types {
+		stmts = append(stmts, m.atTypeRangeSQL(m.typeRanges[types:]...))
+	}
+	return stmts, nil
+}
+
 func (m *Migrate) create(ctx context.Context, tables ...*Table) error {
+	if err := checkStorage(m.Dialect(), tables); err != nil {
+		return err
+	}
+	for _, t := range tables {
+		for _, idx := range t.Indexes {
+			if idx.Expr != "" {
+				return fmt.Errorf("sql/schema: expression index %q is supported only in Atlas migration. Use WithAtlas(true)", idx.Name)
+			}
+		}
+	}
 	tx, err := m.Tx(ctx)
 	if err != nil {
 		return err
@@ -244,10 +347,44 @@ func (m *Migrate) create(ctx context.Context, tables ...*Table) error {
 			return rollback(tx, err)
 		}
 	}
+	backfills, err := m.pendingBackfills(ctx, tx)
+	if err != nil {
+		return rollback(tx, err)
+	}
 	if err := m.txCreate(ctx, tx, tables...); err != nil {
 		return rollback(tx, err)
 	}
-	return tx.Commit()
+	if err := runBackfills(ctx, tx, backfills); err != nil {
+		return rollback(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return m.concurrentIndexes(ctx, tables...)
+}
+
+// concurrentIndexes creates the concurrent indexes of the given tables. They are
+// created after the migration transaction is committed, because Postgres does not
+// allow executing CREATE INDEX CONCURRENTLY inside a transaction block.
+func (m *Migrate) concurrentIndexes(ctx context.Context, tables ...*Table) error {
+	for _, t := range tables {
+		for _, idx := range t.Indexes {
+			if !m.concurrent(idx) {
+				continue
+			}
+			query, args := m.addIndex(idx, t.Name).Query()
+			if err := m.Exec(ctx, query, args, nil); err != nil {
+				return fmt.Errorf("create index %q: %w", idx.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// concurrent reports if the given index should be created
+// concurrently (i.e. outside the migration transaction).
+func (m *Migrate) concurrent(idx *Index) bool {
+	return idx.Concurrent && m.Dialect() == dialect.Postgres
 }
 
 func (m *Migrate) txCreate(ctx context.Context, tx dialect.Tx, tables ...*Table) error {
@@ -285,8 +422,17 @@ func (m *Migrate) txCreate(ctx context.Context, tx dialect.Tx, tables ...*Table)
 					return err
 				}
 			}
+			if err := m.setStorage(ctx, tx, t.Name, t.Columns); err != nil {
+				return err
+			}
+			if err := m.setUnlogged(ctx, tx, t); err != nil {
+				return err
+			}
 			// indexes.
 			for _, idx := range t.Indexes {
+				if m.concurrent(idx) {
+					continue
+				}
 				query, args := m.addIndex(idx, t.Name).Query()
 				if err := tx.Exec(ctx, query, args, nil); err != nil {
 					return fmt.Errorf("create index %q: %w", idx.Name, err)
@@ -328,6 +474,38 @@ func (m *Migrate) txCreate(ctx context.Context, tx dialect.Tx, tables ...*Table)
 	return nil
 }
 
+// pendingBackfills returns the backfills of the columns that do not exist in the database.
+func (m *Migrate) pendingBackfills(ctx context.Context, tx dialect.Tx) ([]*backfill, error) {
+	var pending []*backfill
+	for _, b := range m.backfills {
+		exist, err := m.tableExist(ctx, tx, b.table)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			t, err := m.table(ctx, tx, b.table)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := t.column(b.column); ok {
+				continue
+			}
+		}
+		pending = append(pending, b)
+	}
+	return pending, nil
+}
+
+// runBackfills executes the given backfills in the migration transaction.
+func runBackfills(ctx context.Context, tx dialect.Tx, backfills []*backfill) error {
+	for _, b := range backfills {
+		if err := b.fn(ctx, tx); err != nil {
+			return fmt.Errorf("backfill column %q of table %q: %w", b.column, b.table, err)
+		}
+	}
+	return nil
+}
+
 // apply applies changes on the given table.
 func (m *Migrate) apply(ctx context.Context, tx dialect.Tx, table string, change *changes) error {
 	// Constraints should be dropped before dropping columns, because if a column
@@ -357,7 +535,13 @@ func (m *Migrate) apply(ctx context.Context, tx dialect.Tx, table string, change
 			return fmt.Errorf("alter table %q: %w", table, err)
 		}
 	}
+	if err := m.setStorage(ctx, tx, table, append(change.column.add, change.column.modify...)); err != nil {
+		return err
+	}
 	for _, idx := range change.index.add {
+		if m.concurrent(idx) {
+			continue
+		}
 		query, args := m.addIndex(idx, table).Query()
 		if err := tx.Exec(ctx, query, args, nil); err != nil {
 			return fmt.Errorf("create index %q: %w", table, err)
@@ -366,6 +550,60 @@ func (m *Migrate) apply(ctx context.Context, tx dialect.Tx, table string, change
 	return nil
 }
 
+// setStorage sets the storage mode of the given columns, if it is supported by the dialect.
+func (m *Migrate) setStorage(ctx context.Context, tx dialect.Tx, table string, columns []*Column) error {
+	s, ok := m.sqlDialect.(storageSetter)
+	if !ok {
+		return nil
+	}
+	q := s.setStorage(table, columns)
+	if q == nil {
+		return nil
+	}
+	query, args := q.Query()
+	if err := tx.Exec(ctx, query, args, nil); err != nil {
+		return fmt.Errorf("set columns storage of table %q: %w", table, err)
+	}
+	return nil
+}
+
+// storageModes holds the column storage modes that are supported by PostgreSQL.
+var storageModes = map[string]bool{"PLAIN": true, "MAIN": true, "EXTERNAL": true, "EXTENDED": true}
+
+// checkStorage verifies that the storage modes of the table columns are valid,
+// and are used only by the dialects that support them.
+func checkStorage(name string, tables []*Table) error {
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			switch {
+			case c.Storage == "":
+			case name != dialect.Postgres:
+				return fmt.Errorf("sql/schema: storage mode of column %q.%q is supported only by PostgreSQL", t.Name, c.Name)
+			case !storageModes[strings.ToUpper(c.Storage)]:
+				return fmt.Errorf("sql/schema: invalid storage mode %q for column %q.%q. Expect PLAIN, MAIN, EXTERNAL or EXTENDED", c.Storage, t.Name, c.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// setUnlogged makes the given table unlogged, if it is supported by the dialect.
+func (m *Migrate) setUnlogged(ctx context.Context, tx dialect.Tx, t *Table) error {
+	s, ok := m.sqlDialect.(unloggedSetter)
+	if !ok {
+		return nil
+	}
+	q := s.setUnlogged(t)
+	if q == nil {
+		return nil
+	}
+	query, args := q.Query()
+	if err := tx.Exec(ctx, query, args, nil); err != nil {
+		return fmt.Errorf("set table %q unlogged: %w", t.Name, err)
+	}
+	return nil
+}
+
 // changes to apply on existing table.
 type changes struct {
 	// column changes.
@@ -743,6 +981,24 @@ type fkRenamer interface {
 	renameColumn(*Table, *Column, *Column) sql.Querier
 }
 
+// storageSetter is implemented by dialects that support
+// configuring the storage mode of columns (e.g. Postgres).
+type storageSetter interface {
+	setStorage(table string, columns []*Column) sql.Querier
+}
+
+// constraintDeferrer is implemented by dialects that support altering
+// the deferrability of existing constraints (e.g. Postgres).
+type constraintDeferrer interface {
+	deferConstraints(table string, fks []*ForeignKey) sql.Querier
+}
+
+// unloggedSetter is implemented by dialects that support
+// unlogged tables (e.g. Postgres).
+type unloggedSetter interface {
+	setUnlogged(*Table) sql.Querier
+}
+
 // verifyRanger wraps the method for verifying global-id range correctness.
 type verifyRanger interface {
 	verifyRange(context.Context, dialect.Tx, *Table, int64) error
@@ -799,3 +1055,32 @@ func (s *dbTypeStore) add(ctx context.Context, conn dialect.ExecQuerier, t strin
 }
 
 var _ typeStore = (*dbTypeStore)(nil)
+
+// dryTypeStore wraps the database type store in dry-run mode. It records the statement
+// for creating the types table instead of executing it, and ignores new types.
+type dryTypeStore struct {
+	*dbTypeStore
+	stmts []string
+}
+
+// load the types from the database, if the types table exists.
+func (s *dryTypeStore) load(ctx context.Context, conn dialect.ExecQuerier) ([]string, error) {
+	exists, err := s.drv.tableExist(ctx, conn, TypeTable)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		t := NewTable(TypeTable).
+			AddPrimary(&Column{Name: "id", Type: field.TypeUint, Increment: true}).
+			AddColumn(&Column{Name: "type", Type: field.TypeString, Unique: true})
+		query, _ := s.drv.tBuilder(t).Query()
+		s.stmts = append(s.stmts, query)
+		return nil, nil
+	}
+	return s.dbTypeStore.load(ctx, conn)
+}
+
+// add ignores the given type, as it is added by the migration plan.
+func (*dryTypeStore) add(context.Context, dialect.ExecQuerier, string) error {
+	return nil
+}
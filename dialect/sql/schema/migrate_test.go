@@ -102,6 +102,15 @@ func TestMigrate_Diff(t *testing.T) {
 	require.NoError(t, d.WriteFile("tmp.sql", nil))
 	require.ErrorIs(t, m.Diff(context.Background(), &Table{Name: "users"}), migrate.ErrChecksumMismatch)
 
+	// Test additional changes.
+	p = t.TempDir()
+	d, err = migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	m, err = NewMigrate(db, WithDir(d), WithDiffChanges(&migrate.Change{Cmd: "INSERT INTO users DEFAULT VALUES", Comment: "add default user"}))
+	require.NoError(t, err)
+	require.NoError(t, m.Diff(context.Background(), &Table{Name: "users"}))
+	requireFileEqual(t, filepath.Join(p, v+"_changes.up.sql"), "-- create \"users\" table\nCREATE TABLE `users` (, PRIMARY KEY ());\n-- add default user\nINSERT INTO users DEFAULT VALUES;\n")
+
 	// Test type store.
 	idCol := []*Column{{Name: "id", Type: field.TypeInt, Increment: true}}
 	p = t.TempDir()
@@ -289,3 +298,150 @@ func TestMigrateWithoutForeignKeys(t *testing.T) {
 		require.EqualValues(t, "name", addColumn.C.Name)
 	})
 }
+
+func TestMigrate_Backfill(t *testing.T) {
+	ctx := context.Background()
+	for _, atlas := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Atlas=%t", atlas), func(t *testing.T) {
+			db, err := sql.Open(dialect.SQLite, fmt.Sprintf("file:backfill%t?mode=memory&_fk=1", atlas))
+			require.NoError(t, err)
+			defer db.Close()
+			require.NoError(t, db.Exec(ctx, "CREATE TABLE `users` (`id` integer PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL)", []interface{}{}, nil))
+			require.NoError(t, db.Exec(ctx, "INSERT INTO `users` (`name`) VALUES ('a8m'), ('nati')", []interface{}{}, nil))
+
+			var calls int
+			users := &Table{
+				Name: "users",
+				Columns: []*Column{
+					{Name: "id", Type: field.TypeInt, Increment: true},
+					{Name: "name", Type: field.TypeString},
+					{Name: "nickname", Type: field.TypeString, Nullable: true},
+				},
+			}
+			users.PrimaryKey = users.Columns[:1]
+			m, err := NewMigrate(db, WithAtlas(atlas), WithBackfill("users", "nickname", func(ctx context.Context, tx dialect.Tx) error {
+				calls++
+				return tx.Exec(ctx, "UPDATE `users` SET `nickname` = `name`", []interface{}{}, nil)
+			}))
+			require.NoError(t, err)
+			require.NoError(t, m.Create(ctx, users))
+			require.Equal(t, 1, calls)
+			rows := &sql.Rows{}
+			require.NoError(t, db.Query(ctx, "SELECT COUNT(*) FROM `users` WHERE `nickname` = `name`", []interface{}{}, rows))
+			n, err := sql.ScanInt(rows)
+			require.NoError(t, err)
+			require.Equal(t, 2, n)
+
+			// Backfills are not executed for existing columns.
+			require.NoError(t, m.Create(ctx, users))
+			require.Equal(t, 1, calls)
+
+			// Failed backfills roll back the schema changes.
+			users.Columns = append(users.Columns, &Column{Name: "age", Type: field.TypeInt, Nullable: true})
+			m, err = NewMigrate(db, WithAtlas(atlas), WithBackfill("users", "age", func(context.Context, dialect.Tx) error {
+				return fmt.Errorf("boom")
+			}))
+			require.NoError(t, err)
+			require.EqualError(t, m.Create(ctx, users), `sql/schema: backfill column "age" of table "users": boom`)
+			rows = &sql.Rows{}
+			require.Error(t, db.Query(ctx, "SELECT `age` FROM `users`", []interface{}{}, rows))
+		})
+	}
+}
+
+func TestMigrate_ExprIndex(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open(dialect.SQLite, "file:expr?mode=memory&_fk=1")
+	require.NoError(t, err)
+	defer db.Close()
+	users := &Table{
+		Name: "users",
+		Columns: []*Column{
+			{Name: "id", Type: field.TypeInt, Increment: true},
+			{Name: "name", Type: field.TypeString},
+		},
+		Indexes: []*Index{
+			{Name: "lower_name", Unique: true, Expr: "LOWER(name)"},
+		},
+	}
+	users.PrimaryKey = users.Columns[:1]
+
+	m, err := NewMigrate(db)
+	require.NoError(t, err)
+	require.EqualError(t, m.Create(ctx, users), `sql/schema: expression index "lower_name" is supported only in Atlas migration. Use WithAtlas(true)`)
+
+	m, err = NewMigrate(db, WithAtlas(true))
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, users))
+	require.NoError(t, db.Exec(ctx, "INSERT INTO `users` (`name`) VALUES ('a8m')", []interface{}{}, nil))
+	require.Error(t, db.Exec(ctx, "INSERT INTO `users` (`name`) VALUES ('A8M')", []interface{}{}, nil))
+}
+
+func TestMigrate_ConcurrentIndex(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open(dialect.SQLite, "file:concurrent?mode=memory&_fk=1")
+	require.NoError(t, err)
+	defer db.Close()
+	users := &Table{
+		Name: "users",
+		Columns: []*Column{
+			{Name: "id", Type: field.TypeInt, Increment: true},
+			{Name: "email", Type: field.TypeString},
+		},
+	}
+	users.PrimaryKey = users.Columns[:1]
+	users.Indexes = []*Index{{Name: "user_email", Columns: users.Columns[1:], Concurrent: true}}
+
+	var logs []string
+	m, err := NewMigrate(db, WithAtlas(true), WithLogger(func(v ...interface{}) { logs = append(logs, fmt.Sprint(v...)) }))
+	require.NoError(t, err)
+	require.NoError(t, m.Create(ctx, users))
+	require.Equal(t, []string{`sql/schema: concurrent index "user_email" of table "users" is supported only by Postgres. Creating it regularly`}, logs)
+	rows := &sql.Rows{}
+	require.NoError(t, db.Query(ctx, "SELECT COUNT(*) FROM `sqlite_master` WHERE `type` = 'index' AND `name` = 'user_email'", []interface{}{}, rows))
+	n, err := sql.ScanInt(rows)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+func TestMigrate_DryRun(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open(dialect.SQLite, "file:dryrun?mode=memory&_fk=1")
+	require.NoError(t, err)
+	defer db.Close()
+	idCol := []*Column{{Name: "id", Type: field.TypeInt, Increment: true}}
+	users := &Table{Name: "users", Columns: idCol, PrimaryKey: idCol}
+
+	m, err := NewMigrate(db)
+	require.NoError(t, err)
+	_, err = m.DryRun(ctx, users)
+	require.EqualError(t, err, "sql/schema: DryRun is supported only in Atlas migration. Use WithAtlas(true)")
+
+	m, err = NewMigrate(db, WithAtlas(true))
+	require.NoError(t, err)
+	stmts, err := m.DryRun(ctx, users)
+	require.NoError(t, err)
+	require.Equal(t, []string{"CREATE TABLE `users` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT)"}, stmts)
+	// Statements are not executed.
+	exists, err := m.tableExist(ctx, db, "users")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	m, err = NewMigrate(db, WithAtlas(true), WithGlobalUniqueID(true))
+	require.NoError(t, err)
+	stmts, err = m.DryRun(ctx, users)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"CREATE TABLE `ent_types`(`id` integer PRIMARY KEY AUTOINCREMENT NOT NULL, `type` varchar(255) UNIQUE NOT NULL)",
+		"CREATE TABLE `users` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT)",
+		"INSERT INTO `ent_types` (`type`) VALUES ('users')",
+	}, stmts)
+	exists, err = m.tableExist(ctx, db, TypeTable)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, m.Create(ctx, users))
+	stmts, err = m.DryRun(ctx, users)
+	require.NoError(t, err)
+	require.Empty(t, stmts)
+}
@@ -198,6 +198,9 @@ func (d *MySQL) tBuilder(t *Table) *sql.TableBuilder {
 		}
 		addChecks(b, t.Annotation)
 	}
+	if d.supportsCheck() {
+		addNamedChecks(b, wordsChecks(dialect.MySQL, t))
+	}
 	return b
 }
 
@@ -789,6 +792,9 @@ func (d *MySQL) atOpen(conn dialect.ExecQuerier) (migrate.Driver, error) {
 
 func (d *MySQL) atTable(t1 *Table, t2 *schema.Table) {
 	t2.SetCharset("utf8mb4").SetCollation("utf8mb4_bin")
+	if d.supportsCheck() {
+		setAtNamedChecks(t2, wordsChecks(dialect.MySQL, t1))
+	}
 	if t1.Annotation == nil {
 		return
 	}
@@ -803,15 +809,19 @@ func (d *MySQL) atTable(t1 *Table, t2 *schema.Table) {
 			V: opts,
 		})
 	}
-	// Check if the connected database supports the CHECK clause.
-	// For MySQL, is >= "8.0.16" and for MariaDB it is "10.2.1".
+	if d.supportsCheck() {
+		setAtChecks(t1, t2)
+	}
+}
+
+// supportsCheck reports if the connected database supports the CHECK clause.
+// For MySQL, is >= "8.0.16" and for MariaDB it is "10.2.1".
+func (d *MySQL) supportsCheck() bool {
 	v1, v2 := d.version, "8.0.16"
 	if v, ok := d.mariadb(); ok {
 		v1, v2 = v, "10.2.1"
 	}
-	if compareVersions(v1, v2) >= 0 {
-		setAtChecks(t1, t2)
-	}
+	return compareVersions(v1, v2) >= 0
 }
 
 func (d *MySQL) atTypeC(c1 *Column, c2 *schema.Column) error {
@@ -143,6 +143,50 @@ func TestMySQL_Create(t *testing.T) {
 				mock.ExpectCommit()
 			},
 		},
+		{
+			name: "create new table with words check",
+			tables: []*Table{
+				{
+					Name: "posts",
+					PrimaryKey: []*Column{
+						{Name: "id", Type: field.TypeInt, Increment: true},
+					},
+					Columns: []*Column{
+						{Name: "id", Type: field.TypeInt, Increment: true},
+						{Name: "excerpt", Type: field.TypeString, MaxWords: 50},
+					},
+				},
+			},
+			before: func(mock mysqlMock) {
+				mock.start("8.0.19")
+				mock.tableExists("posts", false)
+				mock.ExpectExec(escape("CREATE TABLE IF NOT EXISTS `posts`(`id` bigint AUTO_INCREMENT NOT NULL, `excerpt` varchar(255) NOT NULL, PRIMARY KEY(`id`), CONSTRAINT `posts_excerpt_words` CHECK (CHAR_LENGTH(TRIM(REGEXP_REPLACE(`excerpt`, '[[:space:]]+', ' '))) - CHAR_LENGTH(REPLACE(TRIM(REGEXP_REPLACE(`excerpt`, '[[:space:]]+', ' ')), ' ', '')) < 50)) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin")).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "create new table with words check 5.7",
+			tables: []*Table{
+				{
+					Name: "posts",
+					PrimaryKey: []*Column{
+						{Name: "id", Type: field.TypeInt, Increment: true},
+					},
+					Columns: []*Column{
+						{Name: "id", Type: field.TypeInt, Increment: true},
+						{Name: "excerpt", Type: field.TypeString, MaxWords: 50},
+					},
+				},
+			},
+			before: func(mock mysqlMock) {
+				mock.start("5.7.23")
+				mock.tableExists("posts", false)
+				mock.ExpectExec(escape("CREATE TABLE IF NOT EXISTS `posts`(`id` bigint AUTO_INCREMENT NOT NULL, `excerpt` varchar(255) NOT NULL, PRIMARY KEY(`id`)) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin")).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
 		{
 			name: "create new table with foreign key",
 			tables: func() []*Table {
@@ -331,6 +331,7 @@ func (d *Postgres) tBuilder(t *Table) *sql.TableBuilder {
 	if t.Annotation != nil {
 		addChecks(b, t.Annotation)
 	}
+	addNamedChecks(b, wordsChecks(dialect.Postgres, t))
 	return b
 }
 
@@ -412,6 +413,50 @@ func (d *Postgres) writeDefault(b *sql.ColumnBuilder, c *Column, clause string)
 	b.Attr(clause + " " + attr)
 }
 
+// setStorage returns the query for setting the storage mode of the given
+// columns, or nil if none of them has a custom storage mode.
+func (d *Postgres) setStorage(table string, columns []*Column) sql.Querier {
+	b := sql.Dialect(dialect.Postgres).AlterTable(table)
+	for _, c := range columns {
+		if c.Storage != "" {
+			b.ModifyColumn(sql.Dialect(dialect.Postgres).Column(c.Name).Attr("SET STORAGE " + strings.ToUpper(c.Storage)))
+		}
+	}
+	if len(b.Queries) == 0 {
+		return nil
+	}
+	return b
+}
+
+// deferConstraints returns the query for making the given foreign-keys
+// deferrable, or nil if none of them is deferrable.
+func (d *Postgres) deferConstraints(table string, fks []*ForeignKey) sql.Querier {
+	b := sql.Dialect(dialect.Postgres).AlterTable(table)
+	for _, fk := range fks {
+		switch {
+		case fk.InitiallyDeferred:
+			b.AlterConstraint(fk.Symbol, "DEFERRABLE INITIALLY DEFERRED")
+		case fk.Deferrable:
+			b.AlterConstraint(fk.Symbol, "DEFERRABLE")
+		}
+	}
+	if len(b.Queries) == 0 {
+		return nil
+	}
+	return b
+}
+
+// setUnlogged returns the query for making the given table
+// unlogged, or nil if it is not annotated as such.
+func (d *Postgres) setUnlogged(t *Table) sql.Querier {
+	if t.Annotation == nil || !t.Annotation.Unlogged {
+		return nil
+	}
+	b := sql.Dialect(dialect.Postgres).AlterTable(t.Name)
+	b.Queries = append(b.Queries, sql.Raw("SET UNLOGGED"))
+	return b
+}
+
 // alterColumn returns list of ColumnBuilder for applying in order to alter a column.
 func (d *Postgres) alterColumn(c *Column) (ops []*sql.ColumnBuilder) {
 	b := sql.Dialect(dialect.Postgres)
@@ -458,6 +503,9 @@ func (d *Postgres) addIndex(i *Index, table string) *sql.IndexBuilder {
 	if i.Unique {
 		idx.Unique()
 	}
+	if i.Concurrent {
+		idx.Concurrently()
+	}
 	for _, c := range i.Columns {
 		idx.Column(c.Name)
 	}
@@ -681,6 +729,7 @@ func (d *Postgres) atTable(t1 *Table, t2 *schema.Table) {
 	if t1.Annotation != nil {
 		setAtChecks(t1, t2)
 	}
+	setAtNamedChecks(t2, wordsChecks(dialect.Postgres, t1))
 }
 
 func (d *Postgres) atTypeC(c1 *Column, c2 *schema.Column) error {
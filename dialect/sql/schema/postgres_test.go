@@ -85,6 +85,28 @@ func TestPostgres_Create(t *testing.T) {
 				mock.ExpectCommit()
 			},
 		},
+		{
+			name: "create new table with words check",
+			tables: []*Table{
+				{
+					Name: "posts",
+					PrimaryKey: []*Column{
+						{Name: "id", Type: field.TypeInt, Increment: true},
+					},
+					Columns: []*Column{
+						{Name: "id", Type: field.TypeInt, Increment: true},
+						{Name: "excerpt", Type: field.TypeString, MaxWords: 50},
+					},
+				},
+			},
+			before: func(mock pgMock) {
+				mock.start("120000")
+				mock.tableExists("posts", false)
+				mock.ExpectExec(escape(`CREATE TABLE IF NOT EXISTS "posts"("id" bigint GENERATED BY DEFAULT AS IDENTITY NOT NULL, "excerpt" varchar NOT NULL, PRIMARY KEY("id"), CONSTRAINT "posts_excerpt_words" CHECK (CHAR_LENGTH(TRIM(REGEXP_REPLACE("excerpt", '[[:space:]]+', ' ', 'g'))) - CHAR_LENGTH(REPLACE(TRIM(REGEXP_REPLACE("excerpt", '[[:space:]]+', ' ', 'g')), ' ', '')) < 50))`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
 		{
 			name: "create new table with foreign key",
 			tables: func() []*Table {
@@ -375,6 +397,151 @@ func TestPostgres_Create(t *testing.T) {
 				mock.ExpectCommit()
 			},
 		},
+		{
+			name: "create new table with deferrable foreign-key",
+			tables: func() []*Table {
+				var (
+					c1 = []*Column{
+						{Name: "id", Type: field.TypeInt, Increment: true},
+						{Name: "sibling_id", Type: field.TypeInt, Nullable: true},
+					}
+					t1 = &Table{
+						Name:       "nodes",
+						Columns:    c1,
+						PrimaryKey: c1[0:1],
+					}
+				)
+				t1.ForeignKeys = []*ForeignKey{
+					{
+						Symbol:            "nodes_sibling",
+						Columns:           c1[1:],
+						RefTable:          t1,
+						RefColumns:        c1[0:1],
+						OnDelete:          SetNull,
+						Deferrable:        true,
+						InitiallyDeferred: true,
+					},
+				}
+				return []*Table{t1}
+			}(),
+			before: func(mock pgMock) {
+				mock.start("120000")
+				mock.tableExists("nodes", false)
+				mock.ExpectExec(escape(`CREATE TABLE IF NOT EXISTS "nodes"("id" bigint GENERATED BY DEFAULT AS IDENTITY NOT NULL, "sibling_id" bigint NULL, PRIMARY KEY("id"))`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.fkExists("nodes_sibling", false)
+				mock.ExpectExec(escape(`ALTER TABLE "nodes" ADD CONSTRAINT "nodes_sibling" FOREIGN KEY("sibling_id") REFERENCES "nodes"("id") ON DELETE SET NULL DEFERRABLE INITIALLY DEFERRED`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "create new table with concurrent index",
+			tables: func() []*Table {
+				c1 := []*Column{
+					{Name: "id", Type: field.TypeInt, Increment: true},
+					{Name: "email", Type: field.TypeString},
+				}
+				t1 := &Table{
+					Name:       "users",
+					Columns:    c1,
+					PrimaryKey: c1[0:1],
+					Indexes: Indexes{
+						{Name: "user_email", Columns: c1[1:], Concurrent: true},
+					},
+				}
+				return []*Table{t1}
+			}(),
+			before: func(mock pgMock) {
+				mock.start("120000")
+				mock.tableExists("users", false)
+				mock.ExpectExec(escape(`CREATE TABLE IF NOT EXISTS "users"("id" bigint GENERATED BY DEFAULT AS IDENTITY NOT NULL, "email" varchar NOT NULL, PRIMARY KEY("id"))`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectExec(escape(`CREATE INDEX CONCURRENTLY IF NOT EXISTS "user_email" ON "users"("email")`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "create new table with columns storage",
+			tables: []*Table{
+				{
+					Name: "posts",
+					Columns: []*Column{
+						{Name: "id", Type: field.TypeInt, Increment: true},
+						{Name: "body", Type: field.TypeString, Storage: "EXTENDED"},
+						{Name: "data", Type: field.TypeBytes, Storage: "EXTERNAL"},
+					},
+					PrimaryKey: []*Column{
+						{Name: "id", Type: field.TypeInt, Increment: true},
+					},
+				},
+			},
+			before: func(mock pgMock) {
+				mock.start("120000")
+				mock.tableExists("posts", false)
+				mock.ExpectExec(escape(`CREATE TABLE IF NOT EXISTS "posts"("id" bigint GENERATED BY DEFAULT AS IDENTITY NOT NULL, "body" varchar NOT NULL, "data" bytea NOT NULL, PRIMARY KEY("id"))`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(escape(`ALTER TABLE "posts" ALTER COLUMN "body" SET STORAGE EXTENDED, ALTER COLUMN "data" SET STORAGE EXTERNAL`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "create new unlogged table",
+			tables: []*Table{
+				{
+					Name: "users_unlogged",
+					Columns: []*Column{
+						{Name: "id", Type: field.TypeInt, Increment: true},
+					},
+					PrimaryKey: []*Column{
+						{Name: "id", Type: field.TypeInt, Increment: true},
+					},
+					Annotation: &entsql.Annotation{Unlogged: true},
+				},
+			},
+			before: func(mock pgMock) {
+				mock.start("120000")
+				mock.tableExists("users_unlogged", false)
+				mock.ExpectExec(escape(`CREATE TABLE IF NOT EXISTS "users_unlogged"("id" bigint GENERATED BY DEFAULT AS IDENTITY NOT NULL, PRIMARY KEY("id"))`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(escape(`ALTER TABLE "users_unlogged" SET UNLOGGED`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "add column with storage",
+			tables: []*Table{
+				{
+					Name: "users",
+					Columns: []*Column{
+						{Name: "id", Type: field.TypeInt, Increment: true},
+						{Name: "data", Type: field.TypeBytes, Nullable: true, Storage: "EXTERNAL"},
+					},
+					PrimaryKey: []*Column{
+						{Name: "id", Type: field.TypeInt, Increment: true},
+					},
+				},
+			},
+			before: func(mock pgMock) {
+				mock.start("120000")
+				mock.tableExists("users", true)
+				mock.ExpectQuery(escape(`SELECT "column_name", "data_type", "is_nullable", "column_default", "udt_name", "numeric_precision", "numeric_scale", "character_maximum_length" FROM "information_schema"."columns" WHERE "table_schema" = CURRENT_SCHEMA() AND "table_name" = $1`)).
+					WithArgs("users").
+					WillReturnRows(sqlmock.NewRows([]string{"column_name", "data_type", "is_nullable", "column_default", "udt_name", "numeric_precision", "numeric_scale", "character_maximum_length"}).
+						AddRow("id", "bigint", "NO", "NULL", "int8", nil, nil, nil))
+				mock.ExpectQuery(escape(fmt.Sprintf(indexesQuery, "CURRENT_SCHEMA()", "users"))).
+					WillReturnRows(sqlmock.NewRows([]string{"index_name", "column_name", "primary", "unique", "seq_in_index"}).
+						AddRow("users_pkey", "id", "t", "t", 0))
+				mock.ExpectExec(escape(`ALTER TABLE "users" ADD COLUMN "data" bytea NULL`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(escape(`ALTER TABLE "users" ALTER COLUMN "data" SET STORAGE EXTERNAL`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
 		{
 			name: "add float column with default value to table",
 			tables: []*Table{
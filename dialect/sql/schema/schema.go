@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema/field"
@@ -232,11 +233,13 @@ func CopyTables(tables []*Table) ([]*Table, error) {
 		}
 		for j, fk := range t.ForeignKeys {
 			cfk := &ForeignKey{
-				Symbol:     fk.Symbol,
-				OnUpdate:   fk.OnUpdate,
-				OnDelete:   fk.OnDelete,
-				Columns:    make([]*Column, len(fk.Columns)),
-				RefColumns: make([]*Column, len(fk.RefColumns)),
+				Symbol:            fk.Symbol,
+				OnUpdate:          fk.OnUpdate,
+				OnDelete:          fk.OnDelete,
+				Deferrable:        fk.Deferrable,
+				InitiallyDeferred: fk.InitiallyDeferred,
+				Columns:           make([]*Column, len(fk.Columns)),
+				RefColumns:        make([]*Column, len(fk.RefColumns)),
 			}
 			for k, c := range fk.Columns {
 				cc, ok := ct.column(c.Name)
@@ -277,6 +280,8 @@ type Column struct {
 	Default    interface{}       // default value.
 	Enums      []string          // enum values.
 	Collation  string            // collation type (utf8mb4_unicode_ci, utf8mb4_general_ci)
+	Storage    string            // storage mode (PLAIN, MAIN, EXTERNAL or EXTENDED). Postgres only.
+	MaxWords   int               // max number of words (CHECK constraint). MySQL and Postgres only.
 	typ        string            // row column type (used for Rows.Scan).
 	indexes    Indexes           // linked indexes.
 	foreign    *ForeignKey       // linked foreign-key.
@@ -437,12 +442,14 @@ func (c *Column) scanTypeOr(t string) string {
 
 // ForeignKey definition for creation.
 type ForeignKey struct {
-	Symbol     string          // foreign-key name. Generated if empty.
-	Columns    []*Column       // table column
-	RefTable   *Table          // referenced table.
-	RefColumns []*Column       // referenced columns.
-	OnUpdate   ReferenceOption // action on update.
-	OnDelete   ReferenceOption // action on delete.
+	Symbol            string          // foreign-key name. Generated if empty.
+	Columns           []*Column       // table column
+	RefTable          *Table          // referenced table.
+	RefColumns        []*Column       // referenced columns.
+	OnUpdate          ReferenceOption // action on update.
+	OnDelete          ReferenceOption // action on delete.
+	Deferrable        bool            // constraint checking can be deferred.
+	InitiallyDeferred bool            // constraint checking is deferred by default.
 }
 
 func (fk ForeignKey) column(name string) (*Column, bool) {
@@ -482,6 +489,9 @@ func (fk ForeignKey) DSL() *sql.ForeignKeyBuilder {
 	if action := string(fk.OnUpdate); action != "" {
 		dsl.OnUpdate(action)
 	}
+	if fk.Deferrable || fk.InitiallyDeferred {
+		dsl.Deferrable(fk.InitiallyDeferred)
+	}
 	return dsl
 }
 
@@ -507,6 +517,8 @@ type Index struct {
 	Name       string                  // index name.
 	Unique     bool                    // uniqueness.
 	Columns    []*Column               // actual table columns.
+	Expr       string                  // index expression.
+	Concurrent bool                    // concurrent creation (Postgres only).
 	Annotation *entsql.IndexAnnotation // index annotation.
 	columns    []string                // columns loaded from query scan.
 	primary    bool                    // primary key index.
@@ -638,18 +650,21 @@ func addChecks(t *sql.TableBuilder, ant *entsql.Annotation) {
 			b.WriteString("CHECK " + checkExpr(check))
 		})
 	}
-	if checks := ant.Checks; len(ant.Checks) > 0 {
-		names := make([]string, 0, len(checks))
-		for name := range checks {
-			names = append(names, name)
-		}
-		sort.Strings(names)
-		for _, name := range names {
-			name := name
-			t.Checks(func(b *sql.Builder) {
-				b.WriteString("CONSTRAINT ").Ident(name).WriteString(" CHECK " + checkExpr(checks[name]))
-			})
-		}
+	addNamedChecks(t, ant.Checks)
+}
+
+// addNamedChecks appends the given named CHECK clauses sorted by their names.
+func addNamedChecks(t *sql.TableBuilder, checks map[string]string) {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		name := name
+		t.Checks(func(b *sql.Builder) {
+			b.WriteString("CONSTRAINT ").Ident(name).WriteString(" CHECK " + checkExpr(checks[name]))
+		})
 	}
 }
 
@@ -661,3 +676,32 @@ func checkExpr(expr string) string {
 	}
 	return expr
 }
+
+// wordsChecks returns the named CHECK expressions that limit the number of words in the
+// table columns. Words are counted by collapsing the whitespace sequences of the value into
+// single spaces, and counting the spaces between them. Unlike the Go validator that splits
+// the value around the characters defined by unicode.IsSpace, the [[:space:]] class is
+// defined by the database (and its locale), and may not match non-ASCII whitespace.
+// SQLite does not provide a REGEXP_REPLACE function, and therefore, no checks are returned.
+func wordsChecks(name string, t *Table) map[string]string {
+	var replace string
+	switch name {
+	case dialect.MySQL:
+		replace = "REGEXP_REPLACE(%s, '[[:space:]]+', ' ')"
+	case dialect.Postgres:
+		replace = "REGEXP_REPLACE(%s, '[[:space:]]+', ' ', 'g')"
+	default:
+		return nil
+	}
+	b := &sql.Builder{}
+	b.SetDialect(name)
+	checks := make(map[string]string)
+	for _, c := range t.Columns {
+		if c.MaxWords <= 0 {
+			continue
+		}
+		value := fmt.Sprintf("TRIM("+replace+")", b.Quote(c.Name))
+		checks[fmt.Sprintf("%s_%s_words", t.Name, c.Name)] = fmt.Sprintf("CHAR_LENGTH(%[1]s) - CHAR_LENGTH(REPLACE(%[1]s, ' ', '')) < %[2]d", value, c.MaxWords)
+	}
+	return checks
+}
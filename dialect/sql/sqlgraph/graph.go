@@ -82,6 +82,9 @@ type Step struct {
 		Columns []string
 		// Inverse indicates if the edge is an inverse edge.
 		Inverse bool
+		// Predicate is an optional predicate that is applied on the
+		// join table of M2M edges, in addition to the columns equality.
+		Predicate func(*sql.Selector)
 	}
 	// To is the dest of the path (the neighbors).
 	To struct {
@@ -158,6 +161,9 @@ func Neighbors(dialect string, s *Step) (q *sql.Selector) {
 		match := builder.Select(join.C(pk1)).
 			From(join).
 			Where(sql.EQ(join.C(pk2), s.From.V))
+		if p := s.Edge.Predicate; p != nil {
+			p(match)
+		}
 		q = builder.Select().
 			From(to).
 			Join(match).
@@ -197,6 +203,9 @@ func SetNeighbors(dialect string, s *Step) (q *sql.Selector) {
 			From(join).
 			Join(set).
 			On(join.C(pk2), set.C(s.From.Column))
+		if p := s.Edge.Predicate; p != nil {
+			p(match)
+		}
 		q = builder.Select().
 			From(to).
 			Join(match).
@@ -229,12 +238,11 @@ func HasNeighbors(q *sql.Selector, s *Step) {
 			pk1 = s.Edge.Columns[1]
 		}
 		join := builder.Table(s.Edge.Table).Schema(s.Edge.Schema)
-		q.Where(
-			sql.In(
-				q.C(s.From.Column),
-				builder.Select(join.C(pk1)).From(join),
-			),
-		)
+		match := builder.Select(join.C(pk1)).From(join)
+		if p := s.Edge.Predicate; p != nil {
+			p(match)
+		}
+		q.Where(sql.In(q.C(s.From.Column), match))
 	case r == M2O || (r == O2O && s.Edge.Inverse):
 		q.Where(sql.NotNull(q.C(s.Edge.Columns[0])))
 	case r == O2M || (r == O2O && !s.Edge.Inverse):
@@ -270,6 +278,9 @@ func HasNeighborsWith(q *sql.Selector, s *Step, pred func(*sql.Selector)) {
 		matches.WithContext(q.Context())
 		pred(matches)
 		join.FromSelect(matches)
+		if p := s.Edge.Predicate; p != nil {
+			p(join)
+		}
 		q.Where(sql.In(q.C(s.From.Column), join))
 	case r == M2O || (r == O2O && s.Edge.Inverse):
 		to := builder.Table(s.To.Table).Schema(s.To.Schema)
@@ -408,6 +419,9 @@ type (
 		Edges     EdgeMut
 		Fields    FieldMut
 		Predicate func(*sql.Selector)
+		// Modifiers are applied on the UPDATE statement after
+		// the fields and edges columns were set.
+		Modifiers []func(*sql.UpdateBuilder)
 
 		ScanValues func(columns []string) ([]interface{}, error)
 		Assign     func(columns []string, values []interface{}) error
@@ -841,7 +855,10 @@ func (u *updater) setTableColumns(update *sql.UpdateBuilder, addEdges, clearEdge
 	for _, fi := range u.Fields.Add {
 		update.Add(fi.Column, fi.Value)
 	}
-	return nil
+	for _, m := range u.Modifiers {
+		m(update)
+	}
+	return update.Err()
 }
 
 func (u *updater) scan(rows *sql.Rows) error {
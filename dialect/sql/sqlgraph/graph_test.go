@@ -514,6 +514,22 @@ func TestHasNeighbors(t *testing.T) {
 			selector:  sql.Select("*").From(sql.Table("users")),
 			wantQuery: "SELECT * FROM `users` WHERE `users`.`id` IN (SELECT `group_users`.`user_id` FROM `group_users`)",
 		},
+		{
+			name: "M2M/2types/predicate",
+			step: func() *Step {
+				step := NewStep(
+					From("users", "id"),
+					To("groups", "id"),
+					Edge(M2M, false, "user_groups", "user_id", "group_id"),
+				)
+				step.Edge.Predicate = func(s *sql.Selector) {
+					s.Where(sql.IsNull(s.C("deleted_at")))
+				}
+				return step
+			}(),
+			selector:  sql.Select("*").From(sql.Table("users")),
+			wantQuery: "SELECT * FROM `users` WHERE `users`.`id` IN (SELECT `user_groups`.`user_id` FROM `user_groups` WHERE `user_groups`.`deleted_at` IS NULL)",
+		},
 		{
 			name: "schema/O2O/1type",
 			step: func() *Step {
@@ -1571,6 +1587,38 @@ func TestUpdateNode(t *testing.T) {
 			},
 			wantUser: &user{name: "Ariel", age: 30, id: 1},
 		},
+		{
+			name: "modifiers",
+			spec: &UpdateSpec{
+				Node: &NodeSpec{
+					Table:   "users",
+					Columns: []string{"id", "name", "age"},
+					ID:      &FieldSpec{Column: "id", Type: field.TypeInt, Value: 1},
+				},
+				Fields: FieldMut{
+					Set: []*FieldSpec{
+						{Column: "age", Type: field.TypeInt, Value: 30},
+					},
+				},
+				Modifiers: []func(*sql.UpdateBuilder){
+					func(u *sql.UpdateBuilder) {
+						u.Set("name", sql.Expr("UPPER(`name`)"))
+					},
+				},
+			},
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(escape("UPDATE `users` SET `age` = ?, `name` = UPPER(`name`) WHERE `id` = ?")).
+					WithArgs(30, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(escape("SELECT `id`, `name`, `age` FROM `users` WHERE `id` = ?")).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "age", "name"}).
+						AddRow(1, 30, "ARIEL"))
+				mock.ExpectCommit()
+			},
+			wantUser: &user{name: "ARIEL", age: 30, id: 1},
+		},
 		{
 			name: "fields/add_set_clear",
 			spec: &UpdateSpec{
@@ -5,9 +5,13 @@
 package sqljson
 
 import (
+	"bytes"
+	stdsql "database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 	"unicode"
 
 	"entgo.io/ent/dialect"
@@ -214,6 +218,32 @@ func StringContains(column string, sub string, opts ...Option) *sql.Predicate {
 	})
 }
 
+// StringKeyEQ return a predicate for checking that the string value of the given key
+// in a JSON object is equal to the given argument. Unlike the Path option, the key is
+// passed to the database as an argument, and therefore, it can hold untrusted input.
+//
+//	sqljson.StringKeyEQ("title", "fr", "Bonjour")
+//
+func StringKeyEQ(column, key, arg string) *sql.Predicate {
+	return sql.P(func(b *sql.Builder) {
+		switch b.Dialect() {
+		case dialect.Postgres:
+			b.Ident(column).WriteString("->>").Arg(key)
+		case dialect.MySQL:
+			b.WriteString("JSON_UNQUOTE").Nested(func(b *sql.Builder) {
+				b.WriteString("JSON_EXTRACT").Nested(func(b *sql.Builder) {
+					b.Ident(column).Comma().WriteString(`CONCAT('$."', `).Arg(key).WriteString(`, '"')`)
+				})
+			})
+		default:
+			b.WriteString("JSON_EXTRACT").Nested(func(b *sql.Builder) {
+				b.Ident(column).Comma().WriteString(`'$."' || `).Arg(key).WriteString(` || '"'`)
+			})
+		}
+		b.WriteOp(sql.OpEQ).Arg(arg)
+	})
+}
+
 // LenEQ return a predicate for checking that an array length
 // of a JSON (returned by the path) is equal to the given argument.
 //
@@ -290,6 +320,217 @@ func LenLTE(column string, size int, opts ...Option) *sql.Predicate {
 	})
 }
 
+// Object returns an expression that builds a JSON object from the given
+// key and column pairs, using the JSON function of the dialect.
+//
+//	sqljson.Object("id", t.C("id"), "name", t.C("name"))
+//
+func Object(pairs ...string) sql.Querier {
+	return sql.ExprFunc(func(b *sql.Builder) {
+		if len(pairs)%2 != 0 {
+			b.AddError(fmt.Errorf("sqljson: odd number of key-column pairs: %d", len(pairs)))
+			return
+		}
+		switch b.Dialect() {
+		case dialect.Postgres:
+			b.WriteString("jsonb_build_object")
+		default:
+			b.WriteString("JSON_OBJECT")
+		}
+		b.Nested(func(b *sql.Builder) {
+			for i := 0; i < len(pairs); i += 2 {
+				if i > 0 {
+					b.Comma()
+				}
+				b.WriteString("'" + strings.ReplaceAll(pairs[i], "'", "''") + "'").Comma()
+				if b.Dialect() != dialect.SQLite {
+					b.Ident(pairs[i+1])
+					continue
+				}
+				// JSON values are stored as BLOBs in SQLite, and cannot be embedded in objects as is.
+				b.WriteString("CASE typeof(").Ident(pairs[i+1]).WriteString(") WHEN 'blob' THEN JSON(CAST(").
+					Ident(pairs[i+1]).WriteString(" AS TEXT)) ELSE ").Ident(pairs[i+1]).WriteString(" END")
+			}
+		})
+	})
+}
+
+// Append sets the given column to an expression that appends the given
+// elements to its JSON array, without reading the column first. A NULL
+// column is treated as an empty array.
+//
+//	sqljson.Append(u, "tags", []interface{}{"a", "b"})
+//
+func Append(u *sql.UpdateBuilder, column string, elems []interface{}) *sql.UpdateBuilder {
+	vs := make([]string, len(elems))
+	for i := range elems {
+		v, err := json.Marshal(elems[i])
+		if err != nil {
+			u.AddError(fmt.Errorf("sqljson: marshal element of column %q: %w", column, err))
+			return u
+		}
+		vs[i] = string(v)
+	}
+	return u.Set(column, sql.ExprFunc(func(b *sql.Builder) {
+		switch b.Dialect() {
+		case dialect.Postgres:
+			b.WriteString("COALESCE").Nested(func(b *sql.Builder) {
+				b.Ident(column).WriteString(", '[]'::jsonb")
+			})
+			b.WriteString(" || ").Arg("[" + strings.Join(vs, ",") + "]").WriteString("::jsonb")
+		case dialect.MySQL:
+			b.WriteString("JSON_ARRAY_APPEND").Nested(func(b *sql.Builder) {
+				b.WriteString("COALESCE").Nested(func(b *sql.Builder) {
+					b.Ident(column).WriteString(", JSON_ARRAY()")
+				})
+				for _, v := range vs {
+					b.Comma().WriteString("'$', CAST(").Arg(v).WriteString(" AS JSON)")
+				}
+			})
+		default:
+			b.WriteString("JSON_INSERT").Nested(func(b *sql.Builder) {
+				b.WriteString("COALESCE").Nested(func(b *sql.Builder) {
+					b.Ident(column).WriteString(", '[]'")
+				})
+				for _, v := range vs {
+					b.Comma().WriteString("'$[#]', JSON(").Arg(v).WriteByte(')')
+				}
+			})
+		}
+	}))
+}
+
+// Merge sets the given column to an expression that merges the given object
+// into its JSON object, without reading the column first. Keys that exist in
+// both objects are set to the given values, and a NULL column is treated as
+// an empty object.
+//
+//	sqljson.Merge(u, "title", map[string]string{"fr": "Bonjour"})
+//
+func Merge(u *sql.UpdateBuilder, column string, obj interface{}) *sql.UpdateBuilder {
+	v, err := json.Marshal(obj)
+	if err != nil {
+		u.AddError(fmt.Errorf("sqljson: marshal object of column %q: %w", column, err))
+		return u
+	}
+	return u.Set(column, sql.ExprFunc(func(b *sql.Builder) {
+		switch b.Dialect() {
+		case dialect.Postgres:
+			b.WriteString("COALESCE").Nested(func(b *sql.Builder) {
+				b.Ident(column).WriteString(", '{}'::jsonb")
+			})
+			b.WriteString(" || ").Arg(string(v)).WriteString("::jsonb")
+		case dialect.MySQL:
+			b.WriteString("JSON_MERGE_PATCH").Nested(func(b *sql.Builder) {
+				b.WriteString("COALESCE").Nested(func(b *sql.Builder) {
+					b.Ident(column).WriteString(", JSON_OBJECT()")
+				})
+				b.Comma().WriteString("CAST(").Arg(string(v)).WriteString(" AS JSON)")
+			})
+		default:
+			b.WriteString("JSON_PATCH").Nested(func(b *sql.Builder) {
+				b.WriteString("COALESCE").Nested(func(b *sql.Builder) {
+					b.Ident(column).WriteString(", '{}'")
+				})
+				b.Comma().WriteString("JSON(").Arg(string(v)).WriteByte(')')
+			})
+		}
+	}))
+}
+
+// ScanObject scans a JSON object that was built by Object, and whose keys are column names.
+// The columns of the object are passed to the scan function for getting their scan values,
+// and the JSON values are converted to the representation returned by the database driver
+// before they are scanned. For example, booleans that are encoded as 0 or 1, and timestamps
+// that are not encoded in RFC 3339 format (as in MySQL and SQLite). The scanned values are
+// passed to the assign function.
+func ScanObject(drv string, data []byte, scan func([]string) ([]interface{}, error), assign func([]string, []interface{}) error) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("sqljson: unmarshal object: %w", err)
+	}
+	columns := make([]string, 0, len(obj))
+	for c := range obj {
+		columns = append(columns, c)
+	}
+	sort.Strings(columns)
+	values, err := scan(columns)
+	if err != nil {
+		return err
+	}
+	for i, c := range columns {
+		if err := scanValue(drv, values[i], obj[c]); err != nil {
+			return fmt.Errorf("sqljson: scan column %q: %w", c, err)
+		}
+	}
+	return assign(columns, values)
+}
+
+// timeLayouts are the layouts of timestamps that are encoded in JSON objects by the
+// supported dialects. PostgreSQL uses RFC 3339, MySQL uses the DATETIME format, and
+// SQLite uses the format that time values are stored in by the SQLite driver.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// scanValue converts the JSON value to its driver representation and scans it into dst.
+func scanValue(drv string, dst interface{}, raw json.RawMessage) error {
+	if b, ok := dst.(*[]byte); ok {
+		// JSON columns are embedded as strings in SQLite objects.
+		var s string
+		if drv == dialect.SQLite && json.Unmarshal(raw, &s) == nil && json.Valid([]byte(s)) {
+			raw = json.RawMessage(s)
+		}
+		if string(raw) != "null" {
+			*b = append((*b)[:0], raw...)
+		}
+		return nil
+	}
+	s, ok := dst.(stdsql.Scanner)
+	if !ok {
+		return fmt.Errorf("unsupported scan type %T", dst)
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+	switch x := v.(type) {
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			v = i
+		} else if v, err = x.Float64(); err != nil {
+			return err
+		}
+	case string:
+		if _, ok := dst.(*stdsql.NullTime); ok {
+			t, err := parseTime(x)
+			if err != nil {
+				return err
+			}
+			v = t
+		}
+	case map[string]interface{}, []interface{}:
+		v = []byte(raw)
+	}
+	return s.Scan(v)
+}
+
+// parseTime parses a timestamp that was encoded in a JSON object.
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unexpected time format: %q", s)
+}
+
 // ValuePath writes to the given SQL builder the JSON path for
 // getting the value of a given JSON path.
 //
@@ -5,8 +5,11 @@
 package sqljson_test
 
 import (
+	stdsql "database/sql"
+	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
@@ -20,6 +23,81 @@ func TestWritePath(t *testing.T) {
 		wantQuery string
 		wantArgs  []interface{}
 	}{
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select().
+				From(sql.Table("users")).
+				SelectExpr(sqljson.Object("id", "id", "name", sql.Table("users").C("name"))),
+			wantQuery: `SELECT jsonb_build_object('id', "id", 'name', "users"."name") FROM "users"`,
+		},
+		{
+			input: sql.Dialect(dialect.MySQL).
+				Select().
+				From(sql.Table("users")).
+				SelectExpr(sqljson.Object("id", "id", "o'k", "name")),
+			wantQuery: "SELECT JSON_OBJECT('id', `id`, 'o''k', `name`) FROM `users`",
+		},
+		{
+			input: sql.Dialect(dialect.SQLite).
+				Select().
+				From(sql.Table("users")).
+				SelectExpr(sqljson.Object("tags", "tags")),
+			wantQuery: "SELECT JSON_OBJECT('tags', CASE typeof(`tags`) WHEN 'blob' THEN JSON(CAST(`tags` AS TEXT)) ELSE `tags` END) FROM `users`",
+		},
+		{
+			input:     sqljson.Append(sql.Dialect(dialect.Postgres).Update("users"), "tags", []interface{}{"a", 1}),
+			wantQuery: `UPDATE "users" SET "tags" = COALESCE("tags", '[]'::jsonb) || $1::jsonb`,
+			wantArgs:  []interface{}{`["a",1]`},
+		},
+		{
+			input:     sqljson.Append(sql.Dialect(dialect.MySQL).Update("users"), "tags", []interface{}{"a", 1}),
+			wantQuery: "UPDATE `users` SET `tags` = JSON_ARRAY_APPEND(COALESCE(`tags`, JSON_ARRAY()), '$', CAST(? AS JSON), '$', CAST(? AS JSON))",
+			wantArgs:  []interface{}{`"a"`, "1"},
+		},
+		{
+			input:     sqljson.Append(sql.Dialect(dialect.SQLite).Update("users"), "tags", []interface{}{"a"}),
+			wantQuery: "UPDATE `users` SET `tags` = JSON_INSERT(COALESCE(`tags`, '[]'), '$[#]', JSON(?))",
+			wantArgs:  []interface{}{`"a"`},
+		},
+		{
+			input:     sqljson.Merge(sql.Dialect(dialect.Postgres).Update("posts"), "title", map[string]string{"fr": "Bonjour"}),
+			wantQuery: `UPDATE "posts" SET "title" = COALESCE("title", '{}'::jsonb) || $1::jsonb`,
+			wantArgs:  []interface{}{`{"fr":"Bonjour"}`},
+		},
+		{
+			input:     sqljson.Merge(sql.Dialect(dialect.MySQL).Update("posts"), "title", map[string]string{"fr": "Bonjour"}),
+			wantQuery: "UPDATE `posts` SET `title` = JSON_MERGE_PATCH(COALESCE(`title`, JSON_OBJECT()), CAST(? AS JSON))",
+			wantArgs:  []interface{}{`{"fr":"Bonjour"}`},
+		},
+		{
+			input:     sqljson.Merge(sql.Dialect(dialect.SQLite).Update("posts"), "title", map[string]string{"fr": "Bonjour"}),
+			wantQuery: "UPDATE `posts` SET `title` = JSON_PATCH(COALESCE(`title`, '{}'), JSON(?))",
+			wantArgs:  []interface{}{`{"fr":"Bonjour"}`},
+		},
+		{
+			input: sql.Dialect(dialect.Postgres).
+				Select("*").
+				From(sql.Table("posts")).
+				Where(sqljson.StringKeyEQ("title", "fr", "Bonjour")),
+			wantQuery: `SELECT * FROM "posts" WHERE "title"->>$1 = $2`,
+			wantArgs:  []interface{}{"fr", "Bonjour"},
+		},
+		{
+			input: sql.Dialect(dialect.MySQL).
+				Select("*").
+				From(sql.Table("posts")).
+				Where(sqljson.StringKeyEQ("title", "fr", "Bonjour")),
+			wantQuery: "SELECT * FROM `posts` WHERE JSON_UNQUOTE(JSON_EXTRACT(`title`, CONCAT('$.\"', ?, '\"'))) = ?",
+			wantArgs:  []interface{}{"fr", "Bonjour"},
+		},
+		{
+			input: sql.Dialect(dialect.SQLite).
+				Select("*").
+				From(sql.Table("posts")).
+				Where(sqljson.StringKeyEQ("title", "fr", "Bonjour")),
+			wantQuery: "SELECT * FROM `posts` WHERE JSON_EXTRACT(`title`, '$.\"' || ? || '\"') = ?",
+			wantArgs:  []interface{}{"fr", "Bonjour"},
+		},
 		{
 			input: sql.Dialect(dialect.Postgres).
 				Select("*").
@@ -381,3 +459,61 @@ func TestParsePath(t *testing.T) {
 		})
 	}
 }
+
+func TestScanObject(t *testing.T) {
+	scan := func(columns []string) ([]interface{}, error) {
+		values := make([]interface{}, len(columns))
+		for i, c := range columns {
+			switch c {
+			case "active":
+				values[i] = new(stdsql.NullBool)
+			case "age":
+				values[i] = new(stdsql.NullInt64)
+			case "created_at":
+				values[i] = new(stdsql.NullTime)
+			case "name":
+				values[i] = new(stdsql.NullString)
+			case "tags":
+				values[i] = new([]byte)
+			default:
+				return nil, fmt.Errorf("unexpected column %q", c)
+			}
+		}
+		return values, nil
+	}
+	tests := []struct {
+		dialect string
+		input   string
+	}{
+		{
+			dialect: dialect.Postgres,
+			input:   `{"active": true, "age": 30, "created_at": "2022-01-02T15:04:05.123456+00:00", "name": "a8m", "tags": ["a"]}`,
+		},
+		{
+			dialect: dialect.MySQL,
+			input:   `{"active": 1, "age": 30, "created_at": "2022-01-02 15:04:05.123456", "name": "a8m", "tags": ["a"]}`,
+		},
+		{
+			dialect: dialect.SQLite,
+			input:   `{"active": 1, "age": 30, "created_at": "2022-01-02 15:04:05.123456+00:00", "name": "a8m", "tags": "[\"a\"]"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			err := sqljson.ScanObject(tt.dialect, []byte(tt.input), scan, func(columns []string, values []interface{}) error {
+				require.Equal(t, []string{"active", "age", "created_at", "name", "tags"}, columns)
+				require.Equal(t, stdsql.NullBool{Bool: true, Valid: true}, *values[0].(*stdsql.NullBool))
+				require.Equal(t, stdsql.NullInt64{Int64: 30, Valid: true}, *values[1].(*stdsql.NullInt64))
+				require.True(t, time.Date(2022, 1, 2, 15, 4, 5, 123456000, time.UTC).Equal(values[2].(*stdsql.NullTime).Time))
+				require.Equal(t, stdsql.NullString{String: "a8m", Valid: true}, *values[3].(*stdsql.NullString))
+				require.JSONEq(t, `["a"]`, string(*values[4].(*[]byte)))
+				return nil
+			})
+			require.NoError(t, err)
+		})
+	}
+	err := sqljson.ScanObject(dialect.MySQL, []byte(`{"created_at": "yesterday"}`), scan, nil)
+	require.EqualError(t, err, `sqljson: scan column "created_at": unexpected time format: "yesterday"`)
+	err = sqljson.ScanObject(dialect.MySQL, []byte(`{"id": 1}`), scan, nil)
+	require.EqualError(t, err, `unexpected column "id"`)
+}
@@ -7,6 +7,7 @@ package ent
 
 import (
 	"context"
+	"errors"
 
 	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/edge"
@@ -342,11 +343,84 @@ type (
 	Hook func(Mutator) Mutator
 )
 
+// ErrSkip may be returned by hooks to indicate that the mutation is a no-op (e.g. no
+// fields were actually changed), and should not be executed. Update and delete
+// operations that were skipped succeed without executing any statement, and bulk
+// operations report 0 affected rows. Note that, create operations do not support
+// skipping, and ErrSkip is returned to their callers as-is.
+//
+//	hook := func(next ent.Mutator) ent.Mutator {
+//		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+//			if len(m.Fields()) == 0 && len(m.ClearedFields()) == 0 {
+//				return nil, ent.ErrSkip
+//			}
+//			return next.Mutate(ctx, m)
+//		})
+//	}
+//
+var ErrSkip = errors.New("ent: skip mutation")
+
 // Mutate calls f(ctx, m).
 func (f MutateFunc) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	return f(ctx, m)
 }
 
+// HookOrder defines the order in which ComposeHooks composes the hooks of a schema and its mixins.
+type HookOrder uint
+
+// Hook composition orders.
+const (
+	SchemaFirst HookOrder = iota // schema hooks are called before the mixin hooks.
+	MixinFirst                   // mixin hooks are called before the schema hooks.
+	Interleaved                  // the n-th hooks of all lists are called before the (n+1)-th hooks.
+)
+
+// ComposeHooks composes the given lists of hooks into one list by the given order. The first
+// list holds the hooks of the schema, and the rest hold the hooks of its mixins, by their order
+// in the schema. Hooks in the returned list are called by their order in the list, and the order
+// of the hooks within each list is preserved.
+//
+//	// Hooks of the user.
+//	func (User) Hooks() []ent.Hook {
+//		return ent.ComposeHooks(
+//			ent.MixinFirst,
+//			[]ent.Hook{hook.On(CheckName, ent.OpCreate)},
+//			AuditMixin{}.Hooks(),
+//			TimeMixin{}.Hooks(),
+//		)
+//	}
+//
+// Note that hooks of mixins that are composed this way should not be returned by the mixins that
+// are embedded in the schema, as they are registered by the codegen in addition to the schema hooks.
+func ComposeHooks(order HookOrder, hooks ...[]Hook) []Hook {
+	var composed []Hook
+	switch {
+	case len(hooks) == 0:
+	case order == MixinFirst:
+		for _, hs := range hooks[1:] {
+			composed = append(composed, hs...)
+		}
+		composed = append(composed, hooks[0]...)
+	case order == Interleaved:
+		for i := 0; ; i++ {
+			n := len(composed)
+			for _, hs := range hooks {
+				if i < len(hs) {
+					composed = append(composed, hs[i])
+				}
+			}
+			if len(composed) == n {
+				break
+			}
+		}
+	default:
+		for _, hs := range hooks {
+			composed = append(composed, hs...)
+		}
+	}
+	return composed
+}
+
 // An Op represents a mutation operation.
 type Op uint
 
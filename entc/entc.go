@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"time"
 
 	"entgo.io/ent/entc/gen"
 	"entgo.io/ent/entc/internal"
@@ -127,6 +128,227 @@ func FeatureNames(names ...string) Option {
 	}
 }
 
+// WithDatadogGenerator enables the generation of the dd package, that provides
+// a client wrapping every query and mutation with a Datadog APM (ddtrace) span.
+//
+//	client := dd.NewInstrumentedClient(base, tracer)
+//
+func WithDatadogGenerator() Option {
+	return func(cfg *gen.Config) error {
+		cfg.Features = append(cfg.Features, gen.FeatureDatadog)
+		return nil
+	}
+}
+
+// WithCacheGenerator enables the generation of the cache package, that wraps the
+// entity clients with a TTL-based cache and invalidates it on mutations. The backend
+// defines the cache backend to generate an adapter for, and can be one of "memory",
+// "redis" or "memcached".
+//
+//	entc.Generate("./schema", &gen.Config{}, entc.WithCacheGenerator("redis"))
+//
+func WithCacheGenerator(backend string) Option {
+	return func(cfg *gen.Config) error {
+		switch backend {
+		case "memory", "redis", "memcached":
+		default:
+			return fmt.Errorf("entc: unsupported cache backend %q", backend)
+		}
+		if err := Annotations(gen.CacheConfig{Backend: backend})(cfg); err != nil {
+			return err
+		}
+		cfg.Features = append(cfg.Features, gen.FeatureCache)
+		return nil
+	}
+}
+
+// WithCachingRepositoryGenerator enables the generation of cached repositories in the cache
+// package, that cache the results of Get and Query calls in-process using a sync.Map, and
+// invalidate them on mutations. The ttl defines the default TTL of the cached entries.
+//
+//	entc.Generate("./schema", &gen.Config{}, entc.WithCachingRepositoryGenerator(time.Minute))
+//
+func WithCachingRepositoryGenerator(ttl time.Duration) Option {
+	return func(cfg *gen.Config) error {
+		if ttl < 0 {
+			return fmt.Errorf("entc: invalid caching repository ttl %s", ttl)
+		}
+		if err := Annotations(gen.CachingRepositoryConfig{TTL: ttl})(cfg); err != nil {
+			return err
+		}
+		cfg.Features = append(cfg.Features, gen.FeatureCachingRepository)
+		return nil
+	}
+}
+
+// WithQueryBenchmarkGenerator enables the generation of benchmarks for the common query shapes
+// of each type (by ID, by unique field and paginated list). The benchmarks run on the Postgres
+// database that is configured by the ENT_BENCH_DSN environment variable, and report the actual
+// rows and execution time of the queries using EXPLAIN ANALYZE.
+//
+//	entc.Generate("./schema", &gen.Config{}, entc.WithQueryBenchmarkGenerator())
+//
+func WithQueryBenchmarkGenerator() Option {
+	return func(cfg *gen.Config) error {
+		cfg.Features = append(cfg.Features, gen.FeatureQueryBenchmark)
+		return nil
+	}
+}
+
+// WithGraphRepositoryGenerator enables the generation of the graph package, that provides
+// an API for traversing multiple hops of the entity graph using the edge names.
+//
+//	entc.Generate("./schema", &gen.Config{}, entc.WithGraphRepositoryGenerator())
+//
+func WithGraphRepositoryGenerator() Option {
+	return func(cfg *gen.Config) error {
+		cfg.Features = append(cfg.Features, gen.FeatureGraphRepository)
+		return nil
+	}
+}
+
+// WithRustSerdeGenerator enables the generation of the models.rs file, that holds the Rust
+// definitions of the entities with serde attributes that match their JSON encoding in Go.
+//
+//	entc.Generate("./schema", &gen.Config{}, entc.WithRustSerdeGenerator())
+//
+func WithRustSerdeGenerator() Option {
+	return func(cfg *gen.Config) error {
+		cfg.Features = append(cfg.Features, gen.FeatureRustSerde)
+		return nil
+	}
+}
+
+// WithFederationGenerator enables the generation of the federation package, that implements
+// the _entities query of Apollo Federation for the entities that are identified by their id.
+//
+//	entc.Generate("./schema", &gen.Config{}, entc.WithFederationGenerator())
+//
+func WithFederationGenerator() Option {
+	return func(cfg *gen.Config) error {
+		cfg.Features = append(cfg.Features, gen.FeatureFederation)
+		return nil
+	}
+}
+
+// WithUnloggedTableGenerator enables the generation of an unlogged copy of each table (e.g.
+// "users_unlogged" for "users"), and an Unlogged<T> client for storing ephemeral entities
+// in it, using the same CRUD API as the entity client.
+//
+//	entc.Generate("./schema", &gen.Config{}, entc.WithUnloggedTableGenerator())
+//
+//	u, err := client.UnloggedUser().Create().SetName("a8m").Save(ctx)
+//
+func WithUnloggedTableGenerator() Option {
+	return func(cfg *gen.Config) error {
+		cfg.Features = append(cfg.Features, gen.FeatureUnloggedTable)
+		return nil
+	}
+}
+
+// WithDeadLetterQueue enables the dead-letter queue of failed schema hooks. Mutations that
+// failed in their schema hooks are written to the store that was configured on the client,
+// and can be replayed later using the Client.Replay method.
+//
+//	client, err := ent.Open(dialect.Postgres, dsn, ent.DeadLetterQueue(store))
+//
+func WithDeadLetterQueue() Option {
+	return func(cfg *gen.Config) error {
+		cfg.Features = append(cfg.Features, gen.FeatureDeadLetterQueue)
+		return nil
+	}
+}
+
+// WithGRPCGatewayGenerator enables the generation of the proto/entpb/entpb.proto file, that
+// defines a CRUD service for each type, with google.api.http annotations mapping the REST
+// routes (e.g. "GET /users/{id}") to its methods, ready for protoc-gen-grpc-gateway.
+//
+//	entc.Generate("./schema", &gen.Config{}, entc.WithGRPCGatewayGenerator())
+//
+func WithGRPCGatewayGenerator() Option {
+	return func(cfg *gen.Config) error {
+		cfg.Features = append(cfg.Features, gen.FeatureGRPCGateway)
+		return nil
+	}
+}
+
+// WithSchemaRegistryGenerator enables the generation of the registry package, that allows
+// plugins to register the entity clients of their schemas at runtime under a label, and the
+// host application to discover them using registry.All().
+//
+//	entc.Generate("./schema", &gen.Config{}, entc.WithSchemaRegistryGenerator())
+//
+func WithSchemaRegistryGenerator() Option {
+	return func(cfg *gen.Config) error {
+		cfg.Features = append(cfg.Features, gen.FeatureSchemaRegistry)
+		return nil
+	}
+}
+
+// WithJSONBChangelogGenerator enables the generation of a hook that records the updates of
+// entities in a changelog table. Only the changed columns are stored, as they are computed
+// by PostgreSQL using the jsonb_diff function that is created by Client.CreateChangelog.
+//
+//	entc.Generate("./schema", &gen.Config{}, entc.WithJSONBChangelogGenerator())
+//
+//	client.Use(ent.ChangelogHook())
+//
+func WithJSONBChangelogGenerator() Option {
+	return func(cfg *gen.Config) error {
+		cfg.Features = append(cfg.Features, gen.FeatureJSONBChangelog)
+		return nil
+	}
+}
+
+// WithDumperGenerator enables the generation of the dumper package, that writes the rows of the
+// given entity types as INSERT statements, ordered by their foreign-key dependencies.
+//
+//	entc.Generate("./schema", &gen.Config{}, entc.WithDumperGenerator())
+//
+//	dumper.DumpSQL(ctx, client, w, "User", "Group")
+//
+func WithDumperGenerator() Option {
+	return func(cfg *gen.Config) error {
+		cfg.Features = append(cfg.Features, gen.FeatureDumper)
+		return nil
+	}
+}
+
+// WithValidationMiddlewareGenerator enables the generation of the middleware package, that holds HTTP
+// middlewares for validating the JSON request bodies of entity creations using the schema validators.
+//
+//	entc.Generate("./schema", &gen.Config{}, entc.WithValidationMiddlewareGenerator())
+//
+//	mux.Handle("/users", middleware.ValidateUserCreate(handler))
+//
+func WithValidationMiddlewareGenerator() Option {
+	return func(cfg *gen.Config) error {
+		cfg.Features = append(cfg.Features, gen.FeatureValidationMiddleware)
+		return nil
+	}
+}
+
+// WithDiagramGenerator enables the generation of an ER diagram of the schema, that depicts all entities,
+// their fields and the cardinality of their edges. The format can be one of "mermaid" or "plantuml", and
+// the diagram is written to the docs/schema.mmd or docs/schema.puml file of the target directory.
+//
+//	entc.Generate("./schema", &gen.Config{}, entc.WithDiagramGenerator("mermaid"))
+//
+func WithDiagramGenerator(format string) Option {
+	return func(cfg *gen.Config) error {
+		switch format {
+		case "mermaid", "plantuml":
+		default:
+			return fmt.Errorf("entc: unsupported diagram format %q", format)
+		}
+		if err := Annotations(gen.DiagramConfig{Format: format})(cfg); err != nil {
+			return err
+		}
+		cfg.Features = append(cfg.Features, gen.FeatureDiagram)
+		return nil
+	}
+}
+
 // Annotation is used to attach arbitrary metadata to the schema objects in codegen.
 // Unlike schema annotations, being serializable to JSON raw value is not mandatory.
 //
@@ -276,18 +498,7 @@ func DependencyType(v interface{}) DependencyOption {
 		if v == nil {
 			return errors.New("nil dependency type")
 		}
-		t := reflect.TypeOf(v)
-		tv := indirect(t)
-		d.Type = &field.TypeInfo{
-			Ident:   t.String(),
-			PkgPath: tv.PkgPath(),
-			RType: &field.RType{
-				Kind:    t.Kind(),
-				Name:    tv.Name(),
-				Ident:   tv.String(),
-				PkgPath: tv.PkgPath(),
-			},
-		}
+		d.Type = typeInfo(v)
 		return nil
 	}
 }
@@ -345,6 +556,44 @@ func Dependency(opts ...DependencyOption) Option {
 	}
 }
 
+// ContextValue generates type-safe accessors for a value with the given name and the
+// type of v that is stored in a context.Context. For example:
+//
+//	entc.ContextValue("UserID", 0)
+//
+// Generates the following functions in the ent package:
+//
+//	func NewUserIDContext(parent context.Context, v int) context.Context
+//	func UserIDFromContext(ctx context.Context) (int, bool)
+//
+func ContextValue(name string, v interface{}) Option {
+	return func(cfg *gen.Config) error {
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return fmt.Errorf("entc: invalid context value name %q", name)
+		}
+		if v == nil {
+			return errors.New("entc: nil context value type")
+		}
+		return Annotations(gen.ContextValues{{Name: name, Type: typeInfo(v)}})(cfg)
+	}
+}
+
+// typeInfo returns the field.TypeInfo of the given value.
+func typeInfo(v interface{}) *field.TypeInfo {
+	t := reflect.TypeOf(v)
+	tv := indirect(t)
+	return &field.TypeInfo{
+		Ident:   t.String(),
+		PkgPath: tv.PkgPath(),
+		RType: &field.RType{
+			Kind:    t.Kind(),
+			Name:    tv.Name(),
+			Ident:   tv.String(),
+			PkgPath: tv.PkgPath(),
+		},
+	}
+}
+
 // templateOption ensures the template instantiate
 // once for config and execute the given Option.
 func templateOption(next func(t *gen.Template) (*gen.Template, error)) Option {
@@ -5,6 +5,7 @@
 package gen
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 )
@@ -107,6 +108,299 @@ var (
 		Description: "Allows users to work with versioned migrations / migration files",
 	}
 
+	// FeatureVersionedSchema provides a feature-flag for recording the version of the schema in
+	// the database on migration, and verifying that the database schema is compatible with the code.
+	FeatureVersionedSchema = Feature{
+		Name:        "sql/versioned-schema",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows verifying that the schema version recorded in the database matches the generated code",
+	}
+
+	// FeaturePaginatedList provides a feature-flag for adding offset-based pagination to the entity clients.
+	FeaturePaginatedList = Feature{
+		Name:        "sql/paginatedlist",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to list entities using offset-based pagination along with their total count in a single query",
+	}
+
+	// FeatureDatadog provides a feature-flag for generating the dd package, which
+	// wraps the queries and mutations of the generated client with Datadog APM spans.
+	FeatureDatadog = Feature{
+		Name:        "datadog",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates a client that wraps every query and mutation with a Datadog APM (ddtrace) span",
+		cleanup: func(c *Config) error {
+			return os.RemoveAll(filepath.Join(c.Target, "dd"))
+		},
+	}
+
+	// FeatureCache provides a feature-flag for generating the cache package, which
+	// wraps the entity clients with a TTL-based cache. The cache backend can be
+	// configured using the CacheConfig annotation.
+	FeatureCache = Feature{
+		Name:        "cache",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates cached entity clients with TTL-based caching and invalidation on mutations",
+		cleanup: func(c *Config) error {
+			return os.RemoveAll(filepath.Join(c.Target, "cache"))
+		},
+	}
+
+	// FeatureCachingRepository provides a feature-flag for generating in-process cached
+	// repositories of the entities in the cache package. The default TTL of the cached
+	// entries can be configured using the CachingRepositoryConfig annotation.
+	FeatureCachingRepository = Feature{
+		Name:        "cache/repo",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates cached entity repositories using sync.Map with TTL-based entries and invalidation on mutations",
+		GraphTemplates: []GraphTemplate{
+			{
+				Name:   "cache/repo",
+				Format: "cache/repo.go",
+			},
+		},
+		cleanup: func(c *Config) error {
+			if err := os.Remove(filepath.Join(c.Target, "cache", "repo.go")); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			// Remove the package directory in case it is empty.
+			_ = os.Remove(filepath.Join(c.Target, "cache"))
+			return nil
+		},
+	}
+
+	// FeatureDeadLetterQueue provides a feature-flag for capturing the mutations that
+	// failed in their schema hooks in a dead-letter queue store, for later replay.
+	FeatureDeadLetterQueue = Feature{
+		Name:        "dlq",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows capturing mutations that failed in their schema hooks in a dead-letter queue for later replay",
+		cleanup: func(c *Config) error {
+			return os.RemoveAll(filepath.Join(c.Target, "dlq.go"))
+		},
+	}
+
+	// FeatureGRPCGateway provides a feature-flag for generating a protobuf definition
+	// of CRUD services, annotated with google.api.http routes for grpc-gateway.
+	FeatureGRPCGateway = Feature{
+		Name:        "grpcgateway",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates .proto files with google.api.http annotations for the grpc-gateway reverse proxy",
+		cleanup: func(c *Config) error {
+			return os.RemoveAll(filepath.Join(c.Target, "proto"))
+		},
+	}
+
+	// FeatureSchemaRegistry provides a feature-flag for generating the registry package,
+	// which allows plugins to register the entity clients of their schemas at runtime.
+	FeatureSchemaRegistry = Feature{
+		Name:        "registry",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates a registry package that allows registering and discovering entity clients at runtime",
+		cleanup: func(c *Config) error {
+			return os.RemoveAll(filepath.Join(c.Target, "registry"))
+		},
+	}
+
+	// FeatureJSONBChangelog provides a feature-flag for generating a hook that records the
+	// changed columns of updated entities in a changelog table, using JSONB diffs in PostgreSQL.
+	FeatureJSONBChangelog = Feature{
+		Name:        "changelog",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates a hook that records only the changed columns of updated entities using PostgreSQL JSONB diffs",
+		cleanup: func(c *Config) error {
+			return os.RemoveAll(filepath.Join(c.Target, "changelog.go"))
+		},
+	}
+
+	// FeatureDumper provides a feature-flag for generating the dumper package, that
+	// writes the rows of the entity types as INSERT statements for seeding or backups.
+	FeatureDumper = Feature{
+		Name:        "dumper",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates a dumper package that writes the rows of the entity types as SQL INSERT statements",
+		cleanup: func(c *Config) error {
+			return os.RemoveAll(filepath.Join(c.Target, "dumper"))
+		},
+	}
+
+	// FeatureValidationMiddleware provides a feature-flag for generating HTTP middlewares
+	// that validate request bodies using the validators of the schema fields.
+	FeatureValidationMiddleware = Feature{
+		Name:        "middleware",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates HTTP middlewares that validate the request bodies of entity creations using the schema field validators",
+		cleanup: func(c *Config) error {
+			return os.RemoveAll(filepath.Join(c.Target, "middleware"))
+		},
+	}
+
+	// FeatureDiagram provides a feature-flag for generating an ER diagram of the schema
+	// in Mermaid or PlantUML format, configured using the DiagramConfig annotation.
+	FeatureDiagram = Feature{
+		Name:        "diagram",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates an ER diagram of the schema in Mermaid or PlantUML format",
+		cleanup: func(c *Config) error {
+			for _, name := range []string{"schema.mmd", "schema.puml"} {
+				if err := os.Remove(filepath.Join(c.Target, "docs", name)); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	// FeatureSchemaExport provides a feature-flag for writing the graph schema to the
+	// schema.json file of the target directory, in a versioned machine-readable format.
+	FeatureSchemaExport = Feature{
+		Name:        "schema/export",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Writes the schema graph in a versioned JSON format to schema.json for external tooling",
+		GraphTemplates: []GraphTemplate{
+			{
+				Name:   "schema/export",
+				Format: "schema.json",
+			},
+		},
+		cleanup: func(c *Config) error {
+			if err := os.Remove(filepath.Join(c.Target, "schema.json")); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return nil
+		},
+	}
+
+	// FeatureQueryBenchmark provides a feature-flag for generating benchmarks for the common
+	// query shapes of each type (by ID, by unique field and paginated list), that report the
+	// actual rows and the execution time of the queries using Postgres EXPLAIN ANALYZE.
+	FeatureQueryBenchmark = Feature{
+		Name:        "bench",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates benchmarks for the common query shapes of each type that report EXPLAIN ANALYZE metrics",
+		GraphTemplates: []GraphTemplate{
+			{
+				Name:   "bench/helper",
+				Format: "bench_test.go",
+				Skip: func(g *Graph) bool {
+					return g.Storage.Name != "sql"
+				},
+			},
+		},
+		cleanup: func(c *Config) error {
+			matches, err := filepath.Glob(filepath.Join(c.Target, "*bench_test.go"))
+			if err != nil {
+				return err
+			}
+			for _, name := range matches {
+				// Skip benchmarks that were not generated by ent.
+				if buf, err := os.ReadFile(name); err != nil || !bytes.Contains(buf, []byte("DO NOT EDIT")) {
+					continue
+				}
+				if err := os.Remove(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	// FeatureGraphRepository provides a feature-flag for generating the graph package, that
+	// provides an API for traversing multiple hops of the entity graph by the edge names.
+	FeatureGraphRepository = Feature{
+		Name:        "graph",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates a graph traversal API for querying multiple hops of edges using their names",
+		GraphTemplates: []GraphTemplate{
+			{
+				Name:   "graph/graph",
+				Format: "graph/graph.go",
+				Skip: func(g *Graph) bool {
+					return g.Storage.Name != "sql"
+				},
+			},
+		},
+		cleanup: func(c *Config) error {
+			if err := os.Remove(filepath.Join(c.Target, "graph", "graph.go")); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			// Remove the package directory in case it is empty.
+			_ = os.Remove(filepath.Join(c.Target, "graph"))
+			return nil
+		},
+	}
+
+	// FeatureRustSerde provides a feature-flag for generating the models.rs file, that holds
+	// serde-compatible Rust structs of the entities for sharing them with Rust services.
+	FeatureRustSerde = Feature{
+		Name:        "rust/serde",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates serde-compatible Rust struct definitions of the entities in models.rs",
+		GraphTemplates: []GraphTemplate{
+			{
+				Name:   "rust/models",
+				Format: "models.rs",
+			},
+		},
+		cleanup: func(c *Config) error {
+			if err := os.Remove(filepath.Join(c.Target, "models.rs")); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return nil
+		},
+	}
+
+	// FeatureFederation provides a feature-flag for generating the federation package, that
+	// resolves the entities of the Apollo Federation _entities query using the ent client.
+	FeatureFederation = Feature{
+		Name:        "federation",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates Apollo Federation entity resolvers that resolve entities by their id using the client",
+		GraphTemplates: []GraphTemplate{
+			{
+				Name:   "federation/federation",
+				Format: "federation/federation.go",
+			},
+		},
+		cleanup: func(c *Config) error {
+			if err := os.Remove(filepath.Join(c.Target, "federation", "federation.go")); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			// Remove the package directory in case it is empty.
+			_ = os.Remove(filepath.Join(c.Target, "federation"))
+			return nil
+		},
+	}
+
+	// FeatureUnloggedTable provides a feature-flag for generating an unlogged copy of each table,
+	// and an Unlogged<T> client for storing ephemeral entities in it using the same CRUD API.
+	FeatureUnloggedTable = Feature{
+		Name:        "sql/unlogged",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates UNLOGGED copies of the tables, and clients for storing ephemeral entities in them",
+		cleanup: func(c *Config) error {
+			return os.RemoveAll(filepath.Join(c.Target, "unlogged.go"))
+		},
+	}
+
 	// AllFeatures holds a list of all feature-flags.
 	AllFeatures = []Feature{
 		FeaturePrivacy,
@@ -118,6 +412,24 @@ var (
 		FeatureExecQuery,
 		FeatureUpsert,
 		FeatureVersionedMigration,
+		FeatureVersionedSchema,
+		FeaturePaginatedList,
+		FeatureDatadog,
+		FeatureCache,
+		FeatureCachingRepository,
+		FeatureDeadLetterQueue,
+		FeatureGRPCGateway,
+		FeatureSchemaRegistry,
+		FeatureJSONBChangelog,
+		FeatureDumper,
+		FeatureValidationMiddleware,
+		FeatureDiagram,
+		FeatureSchemaExport,
+		FeatureQueryBenchmark,
+		FeatureGraphRepository,
+		FeatureRustSerde,
+		FeatureFederation,
+		FeatureUnloggedTable,
 	}
 )
 
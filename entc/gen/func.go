@@ -86,7 +86,8 @@ func quote(v interface{}) interface{} {
 func fieldOps(f *Field) (ops []Op) {
 	switch t := f.Type.Type; {
 	case f.HasGoType() && !f.ConvertedToBasic() && !f.Type.Valuer():
-	case t == field.TypeJSON:
+	// Encrypted values are randomized, and cannot be compared in the database.
+	case t == field.TypeJSON || f.IsArray() || f.Encrypted():
 	case t == field.TypeBool:
 		ops = boolOps
 	case t == field.TypeString && strings.ToLower(f.Name) != "id":
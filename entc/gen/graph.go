@@ -7,6 +7,8 @@ package gen
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"go/parser"
@@ -18,8 +20,10 @@ import (
 	"strings"
 	"text/template/parse"
 
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/entc/load"
+	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 
 	"golang.org/x/tools/imports"
@@ -47,6 +51,20 @@ type (
 		// 'ent generate' uses "<project>/ent" as a default package.
 		Package string
 
+		// PackageName overrides the name of the generated package, which defaults
+		// to the last element of Package. It allows generating the ent code into an
+		// existing package (e.g. "myapp") rather than into a dedicated "ent" package.
+		//
+		//	&gen.Config{
+		//		Target:      "./",
+		//		Package:     "github.com/org/myapp",
+		//		PackageName: "myapp",
+		//	}
+		//
+		// Note that the generated sub-packages (e.g. hook or enttest) import the generated
+		// package using an alias in case its name conflicts with one of their imports.
+		PackageName string
+
 		// Header allows users to provides an optional header signature for
 		// the generated files. It defaults to the standard 'go generate'
 		// format: '// Code generated by ent, DO NOT EDIT.'.
@@ -66,6 +84,16 @@ type (
 		// the execution output is stored in a file derived by the template name.
 		Templates []*Template
 
+		// TemplateDir specifies an optional directory to load additional templates
+		// from. The templates in the directory are parsed on code generation, and
+		// merged with the default templates and the ones defined in Templates.
+		//
+		//	&gen.Config{
+		//		TemplateDir: "./ent/templates",
+		//	}
+		//
+		TemplateDir string
+
 		// Features defines a list of additional features to add to the codegen phase.
 		// For example, the PrivacyFeature.
 		Features []Feature
@@ -73,6 +101,48 @@ type (
 		// Hooks holds an optional list of Hooks to apply on the graph before/after the code-generation.
 		Hooks []Hook
 
+		// PostGenerateHook is an optional function that is called with the target directory
+		// after all files were written and formatted. For example, for running "go vet" on the
+		// generated package, or for updating the documentation. Since it runs in the same process
+		// as the codegen, it can use the Graph (e.g. by a closure) for metadata-driven processing.
+		//
+		//	&gen.Config{
+		//		PostGenerateHook: func(dir string) error {
+		//			return exec.Command("go", "vet", dir+"/...").Run()
+		//		},
+		//	}
+		//
+		PostGenerateHook func(dir string) error
+
+		// InjectTxIntoContext configures the generated mutation builders to attach the transactional
+		// client to the context that is passed to the mutation hooks, in case the mutation is executed
+		// in a transaction. Hence, hooks can use TxFromContext for executing queries in the transaction.
+		//
+		//	tx := ent.TxFromContext(ctx)
+		//	if tx == nil {
+		//		return nil, errors.New("mutation is not running in a transaction")
+		//	}
+		//
+		InjectTxIntoContext bool
+
+		// GenerateTestHelpers enables the generation of a testing.go file in the generated
+		// package that holds a NewTestClient helper. Unlike the enttest package, it allows
+		// creating a migrated client in tests without importing an additional package.
+		//
+		// Note that the generated file is guarded by the "testonly" build tag, and therefore,
+		// tests that use it should be executed with 'go test -tags testonly'.
+		GenerateTestHelpers bool
+
+		// EntInterface enables the generation of the Entity interface in the generated package,
+		// that is implemented by all entities. It allows writing generic code that works with all
+		// entity types, without using reflection.
+		//
+		//	func Describe(e ent.Entity) string {
+		//		return fmt.Sprintf("%s(%v)", e.GetLabel(), e.GetID())
+		//	}
+		//
+		EntInterface bool
+
 		// Annotations that are injected to the Config object can be accessed
 		// globally in all templates. In order to access an annotation from a
 		// graph template, do the following:
@@ -157,6 +227,13 @@ func NewGraph(c *Config, schemas ...*load.Schema) (g *Graph, err error) {
 		check(t.setupFKs(), "set %q foreign-keys", t.Name)
 	}
 	check(g.edgeSchemas(), "resolving edges")
+	check(g.edgeOrders(), "resolving edge orders")
+	check(g.edgePreloads(), "resolving edge preloads")
+	check(g.edgeMappedFields(), "resolving edge field mappings")
+	check(g.edgeJoinFilters(), "resolving edge join filters")
+	check(g.edgeContexts(), "resolving edge contexts")
+	check(g.edgeCascades(), "resolving edge cascades")
+	check(g.packageName(), "resolving package name")
 	for i := range schemas {
 		g.addIndexes(schemas[i])
 	}
@@ -165,6 +242,24 @@ func NewGraph(c *Config, schemas ...*load.Schema) (g *Graph, err error) {
 	return
 }
 
+// packageName ensures the custom package name is a valid identifier,
+// and that it does not conflict with the packages of the schema types.
+func (g *Graph) packageName() error {
+	name := g.PackageName
+	if name == "" {
+		return nil
+	}
+	if !token.IsIdentifier(name) {
+		return fmt.Errorf("invalid package name %q", name)
+	}
+	for _, n := range g.Nodes {
+		if n.PackageDir() == name {
+			return fmt.Errorf("package name %q conflicts with the package of type %q", name, n.Name)
+		}
+	}
+	return nil
+}
+
 // defaultIDType holds the default value for IDType.
 var defaultIDType = &field.TypeInfo{Type: field.TypeInt}
 
@@ -196,19 +291,30 @@ func (g *Graph) Gen() error {
 	for i := len(g.Hooks) - 1; i >= 0; i-- {
 		gen = g.Hooks[i](gen)
 	}
-	return gen.Generate(g)
+	if err := gen.Generate(g); err != nil {
+		return err
+	}
+	if g.PostGenerateHook != nil {
+		if err := g.PostGenerateHook(g.Config.Target); err != nil {
+			return fmt.Errorf("post-generate hook: %w", err)
+		}
+	}
+	return nil
 }
 
 // generate is the default Generator implementation.
 func generate(g *Graph) error {
-	var (
-		assets   assets
-		external []GraphTemplate
-	)
-	templates, external = g.templates()
+	var assets assets
+	templates, external, err := g.templates()
+	if err != nil {
+		return err
+	}
 	for _, n := range g.Nodes {
 		assets.addDir(filepath.Join(g.Config.Target, n.PackageDir()))
 		for _, tmpl := range Templates {
+			if tmpl.Skip != nil && tmpl.Skip(g) {
+				continue
+			}
 			b := bytes.NewBuffer(nil)
 			if err := templates.ExecuteTemplate(b, tmpl.Name, n); err != nil {
 				return fmt.Errorf("execute template %q: %w", tmpl.Name, err)
@@ -242,6 +348,11 @@ func generate(g *Graph) error {
 	if err := assets.write(); err != nil {
 		return err
 	}
+	if !g.GenerateTestHelpers {
+		if err := cleanTestHelpers(g.Config.Target); err != nil {
+			return fmt.Errorf("cleanup test helpers: %w", err)
+		}
+	}
 	// cleanup assets that are not needed anymore.
 	cleanOldNodes(assets, g.Config.Target)
 	// We can't run "imports" on files when the state is not completed.
@@ -263,6 +374,48 @@ func (g *Graph) addIndexes(schema *load.Schema) {
 	for _, idx := range schema.Indexes {
 		check(typ.AddIndex(idx), "invalid index for schema %q", schema.Name)
 	}
+	for _, e := range typ.PolymorphicEdges {
+		idx := &load.Index{Fields: []string{e.TypeField.Name, e.IDField.Name}}
+		check(typ.AddIndex(idx), "invalid index for polymorphic edge %s.%s", schema.Name, e.Name)
+	}
+}
+
+// addPolymorphicEdge adds a polymorphic edge to the given type, and the
+// fields that store the type name and the id of the entity it points to.
+func (g *Graph) addPolymorphicEdge(t *Type, e *load.Edge) {
+	expect(e.Ref == nil && e.RefName == "", "polymorphic edge %s.%s cannot have a back-reference", t.Name, e.Name)
+	expect(e.Field == "" && e.Through == nil, "polymorphic edge %s.%s cannot be defined with Field or Through", t.Name, e.Name)
+	pe := &PolymorphicEdge{def: e, Name: e.Name, Owner: t}
+	enums := make([]struct{ N, V string }, 0, len(e.Polymorphic))
+	for _, name := range e.Polymorphic {
+		typ, ok := g.typ(name)
+		expect(ok, "type %q does not exist for polymorphic edge %s.%s", name, t.Name, e.Name)
+		expect(typ.HasOneFieldID(), "type %q of polymorphic edge %s.%s must have an id field", name, t.Name, e.Name)
+		if len(pe.Types) > 0 {
+			expect(typ.ID.Type.String() == pe.Types[0].ID.Type.String(), "types %q and %q of polymorphic edge %s.%s must have the same id type", pe.Types[0].Name, name, t.Name, e.Name)
+		}
+		typ.noder = true
+		pe.Types = append(pe.Types, typ)
+		enums = append(enums, struct{ N, V string }{N: name, V: name})
+	}
+	for _, f := range []*load.Field{
+		{Name: e.Name + "_type", Info: &field.TypeInfo{Type: field.TypeEnum}, Enums: enums, Optional: !e.Required},
+		{Name: e.Name + "_id", Info: pe.Types[0].ID.Type, Optional: !e.Required},
+	} {
+		tf := &Field{
+			cfg:       g.Config,
+			def:       f,
+			Name:      f.Name,
+			Type:      f.Info,
+			Optional:  f.Optional,
+			StructTag: structTag(f.Name, ""),
+		}
+		check(t.checkField(tf, f), "polymorphic edge %s.%s", t.Name, e.Name)
+		t.Fields = append(t.Fields, tf)
+		t.fields[f.Name] = tf
+	}
+	pe.TypeField, pe.IDField = t.fields[e.Name+"_type"], t.fields[e.Name+"_id"]
+	t.PolymorphicEdges = append(t.PolymorphicEdges, pe)
 }
 
 // addEdges adds the node edges to the graph.
@@ -270,13 +423,17 @@ func (g *Graph) addEdges(schema *load.Schema) {
 	t, _ := g.typ(schema.Name)
 	seen := make(map[string]struct{}, len(schema.Edges))
 	for _, e := range schema.Edges {
-		typ, ok := g.typ(e.Type)
-		expect(ok, "type %q does not exist for edge", e.Type)
-		_, ok = t.fields[e.Name]
-		expect(!ok, "%s schema cannot contain field and edge with the same name %q", schema.Name, e.Name)
-		_, ok = seen[e.Name]
+		_, ok := seen[e.Name]
 		expect(!ok, "%s schema contains multiple %q edges", schema.Name, e.Name)
 		seen[e.Name] = struct{}{}
+		_, ok = t.fields[e.Name]
+		expect(!ok, "%s schema cannot contain field and edge with the same name %q", schema.Name, e.Name)
+		if len(e.Polymorphic) > 0 {
+			g.addPolymorphicEdge(t, e)
+			continue
+		}
+		typ, ok := g.typ(e.Type)
+		expect(ok, "type %q does not exist for edge", e.Type)
 		switch {
 		// Assoc only.
 		case !e.Inverse:
@@ -553,6 +710,176 @@ func (g *Graph) edgeSchemas() error {
 	return nil
 }
 
+// edgeOrders resolves the fields of the edges that were defined with a default
+// order (using OrderBy), and marks their types as being queried with default order.
+func (g *Graph) edgeOrders() error {
+	for _, n := range g.Nodes {
+		for _, e := range n.Edges {
+			o := e.def.OrderBy
+			if o == nil {
+				continue
+			}
+			if e.Unique {
+				return fmt.Errorf("edge %s.%s: OrderBy is not supported on unique edges", n.Name, e.Name)
+			}
+			if d := strings.ToUpper(o.Direction); d != "ASC" && d != "DESC" {
+				return fmt.Errorf("edge %s.%s: invalid OrderBy direction %q, expect ASC or DESC", n.Name, e.Name, o.Direction)
+			}
+			f, ok := e.Type.fields[o.Field]
+			if !ok && e.Type.HasOneFieldID() && e.Type.ID.Name == o.Field {
+				f, ok = e.Type.ID, true
+			}
+			if !ok {
+				return fmt.Errorf("edge %s.%s: OrderBy field %q was not found in type %s", n.Name, e.Name, o.Field, e.Type.Name)
+			}
+			e.order = f
+			e.Type.defaultOrder = true
+		}
+	}
+	return nil
+}
+
+// edgePreloads validates the edges that were defined to be preloaded with their parents.
+func (g *Graph) edgePreloads() error {
+	for _, n := range g.Nodes {
+		for _, e := range n.Edges {
+			switch mode := e.def.Preload; {
+			case mode == "":
+				continue
+			case mode != edge.InlineJSON:
+				return fmt.Errorf("edge %s.%s: unknown Preload mode %q", n.Name, e.Name, mode)
+			case !e.Unique || e.Through != nil:
+				return fmt.Errorf("edge %s.%s: Preload is supported only on unique edges", n.Name, e.Name)
+			case g.Storage.Name != "sql":
+				return fmt.Errorf("edge %s.%s: Preload is not supported by the %s storage", n.Name, e.Name, g.Storage.Name)
+			}
+			for _, f := range n.Fields {
+				if f.StorageKey() == e.InlineColumn() {
+					return fmt.Errorf("edge %s.%s: preloaded column %q conflicts with field %q", n.Name, e.Name, e.InlineColumn(), f.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// edgeMappedFields validates the edges that expose their foreign-key value using a getter.
+func (g *Graph) edgeMappedFields() error {
+	for _, n := range g.Nodes {
+		for _, e := range n.MappedEdges() {
+			switch name := e.MappedField(); {
+			case g.Storage.Name != "sql":
+				return fmt.Errorf("edge %s.%s: MapToField is not supported by the %s storage", n.Name, e.Name, g.Storage.Name)
+			case !e.OwnFK():
+				return fmt.Errorf("edge %s.%s: MapToField is supported only on unique edges that hold the foreign-key", n.Name, e.Name)
+			case e.Field() != nil:
+				return fmt.Errorf("edge %s.%s: MapToField cannot be used on edges with an edge-field (%q)", n.Name, e.Name, e.Field().Name)
+			case !token.IsIdentifier(name):
+				return fmt.Errorf("edge %s.%s: invalid MapToField name %q", n.Name, e.Name, e.def.MapToField)
+			default:
+				members := g.modelMembers(n)
+				if m, ok := members[name]; ok {
+					return fmt.Errorf("edge %s.%s: MapToField name %q conflicts with %s", n.Name, e.Name, e.def.MapToField, m)
+				}
+				for _, other := range n.MappedEdges() {
+					if other != e && other.MappedField() == name {
+						return fmt.Errorf("edge %s.%s: MapToField name %q conflicts with the MapToField of edge %q", n.Name, e.Name, e.def.MapToField, other.Name)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// modelMembers returns the exported struct fields and methods that are generated
+// for the model of the given type, mapped to their description.
+func (g *Graph) modelMembers(n *Type) map[string]string {
+	members := make(map[string]string)
+	method := func(name string) { members[name] = fmt.Sprintf("method %q", name) }
+	for _, name := range []string{"String", "Update", "Unwrap"} {
+		method(name)
+	}
+	if g.EntInterface {
+		method("GetID")
+		method("GetField")
+	}
+	if n.HasOneFieldID() {
+		members[n.ID.StructField()] = fmt.Sprintf("field %q", n.ID.Name)
+	}
+	for _, f := range n.Fields {
+		members[f.StructField()] = fmt.Sprintf("field %q", f.Name)
+	}
+	if len(n.Edges) > 0 {
+		members["Edges"] = `struct field "Edges"`
+	}
+	for _, e := range n.Edges {
+		method("Query" + e.StructField())
+	}
+	for _, e := range n.PolymorphicEdges {
+		method("Query" + e.StructField())
+	}
+	for _, f := range n.PhoneFields() {
+		method(f.StructField() + "Formatted")
+	}
+	for _, f := range n.HumanReadableFields() {
+		method(f.StructField() + "Human")
+	}
+	for _, f := range n.LocalizedFields() {
+		method(f.StructField() + "In")
+	}
+	return members
+}
+
+// edgeJoinFilters validates the edges that filter their join tables.
+func (g *Graph) edgeJoinFilters() error {
+	for _, n := range g.Nodes {
+		for _, e := range n.JoinFilterEdges() {
+			switch {
+			case g.Storage.Name != "sql":
+				return fmt.Errorf("edge %s.%s: JoinFilter is not supported by the %s storage", n.Name, e.Name, g.Storage.Name)
+			case e.Through == nil:
+				return fmt.Errorf("edge %s.%s: JoinFilter is supported only on edges with an edge schema (Through)", n.Name, e.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// edgeContexts validates the edges that were defined with a context function.
+func (g *Graph) edgeContexts() error {
+	for _, n := range g.Nodes {
+		for _, e := range n.ContextEdges() {
+			if g.Storage.Name != "sql" {
+				return fmt.Errorf("edge %s.%s: WithContext is not supported by the %s storage", n.Name, e.Name, g.Storage.Name)
+			}
+			e.Type.edgeContext = true
+		}
+	}
+	return nil
+}
+
+// edgeCascades validates the edges that propagate the deletions of their parent entities.
+func (g *Graph) edgeCascades() error {
+	for _, n := range g.Nodes {
+		for _, e := range n.Edges {
+			switch mode := e.def.Cascade; {
+			case mode == "":
+				continue
+			case mode != edge.SoftDelete:
+				return fmt.Errorf("edge %s.%s: unknown Cascade mode %q", n.Name, e.Name, mode)
+			case g.Storage.Name != "sql":
+				return fmt.Errorf("edge %s.%s: Cascade is not supported by the %s storage", n.Name, e.Name, g.Storage.Name)
+			case n.SoftDeleteField() == nil || e.Type.SoftDeleteField() == nil:
+				return fmt.Errorf("edge %s.%s: Cascade(edge.SoftDelete) requires %s and %s to be defined with the SoftDelete mixin", n.Name, e.Name, n.Name, e.Type.Name)
+			case n.HasCompositeID() || e.IsInverse() || !(e.O2M() || e.O2O()) || e.OwnFK():
+				return fmt.Errorf("edge %s.%s: Cascade(edge.SoftDelete) is supported only on O2M and O2O edges that hold the foreign-key in the edge table", n.Name, e.Name)
+			}
+		}
+	}
+	return nil
+}
+
 // Tables returns the schema definitions of SQL tables for the graph.
 func (g *Graph) Tables() (all []*schema.Table, err error) {
 	tables := make(map[string]*schema.Table)
@@ -566,6 +893,9 @@ func (g *Graph) Tables() (all []*schema.Table, err error) {
 			if !f.IsEdgeField() {
 				table.AddColumn(f.Column())
 			}
+			if f.Compressed() {
+				table.AddColumn(&schema.Column{Name: f.CompressedStorageKey(), Type: field.TypeBool, Default: false})
+			}
 		}
 		tables[table.Name] = table
 		all = append(all, table)
@@ -589,13 +919,13 @@ func (g *Graph) Tables() (all []*schema.Table, err error) {
 					column.Nullable = false
 				}
 				mayAddColumn(owner, column)
-				owner.AddForeignKey(&schema.ForeignKey{
+				owner.AddForeignKey(deferrable(e, &schema.ForeignKey{
 					RefTable:   ref,
 					OnDelete:   deleteAction(e, column),
 					Columns:    []*schema.Column{column},
 					RefColumns: []*schema.Column{ref.PrimaryKey[0]},
 					Symbol:     fkSymbol(e, owner, ref),
-				})
+				}))
 			case M2O:
 				ref, owner := tables[e.Type.Table()], tables[e.Rel.Table]
 				pk := ref.PrimaryKey[0]
@@ -606,13 +936,13 @@ func (g *Graph) Tables() (all []*schema.Table, err error) {
 					column.Nullable = false
 				}
 				mayAddColumn(owner, column)
-				owner.AddForeignKey(&schema.ForeignKey{
+				owner.AddForeignKey(deferrable(e, &schema.ForeignKey{
 					RefTable:   ref,
 					OnDelete:   deleteAction(e, column),
 					Columns:    []*schema.Column{column},
 					RefColumns: []*schema.Column{ref.PrimaryKey[0]},
 					Symbol:     fkSymbol(e, owner, ref),
-				})
+				}))
 			case M2M:
 				// If there is an edge schema for the association (i.e. edge.Through).
 				if e.Through != nil || e.Ref != nil && e.Ref.Through != nil {
@@ -665,11 +995,68 @@ func (g *Graph) Tables() (all []*schema.Table, err error) {
 			// Set the entsql.IndexAnnotation from the schema if exists.
 			index, _ := table.Index(idx.Name)
 			index.Annotation = entsqlIndexAnnotate(idx.Annotations)
+			if idx.Expr != nil {
+				index.Expr = idx.Expr.Expr
+			}
+			index.Concurrent = idx.Concurrent
+		}
+	}
+	if g.featureEnabled(FeatureUnloggedTable) {
+		for _, n := range g.Nodes {
+			if n.HasOneFieldID() {
+				all = append(all, unloggedTable(tables[n.Table()], n.UnloggedTable()))
+			}
 		}
 	}
 	return
 }
 
+// unloggedTable returns an unlogged copy of the given table with the given name. Foreign-keys
+// are not copied, as the ephemeral rows of the copy are not expected to reference (or be referenced
+// by) the rows of the logged tables, and indexes are suffixed with "_unlogged" to avoid conflicts.
+func unloggedTable(t *schema.Table, name string) *schema.Table {
+	u := schema.NewTable(name)
+	for _, c := range t.Columns {
+		u.AddColumn(&schema.Column{
+			Name:       c.Name,
+			Type:       c.Type,
+			SchemaType: c.SchemaType,
+			Attr:       c.Attr,
+			Size:       c.Size,
+			Key:        c.Key,
+			Unique:     c.Unique,
+			Increment:  c.Increment,
+			Nullable:   c.Nullable,
+			Default:    c.Default,
+			Enums:      c.Enums,
+			Collation:  c.Collation,
+			Storage:    c.Storage,
+		})
+	}
+	for _, c := range t.PrimaryKey {
+		for _, uc := range u.Columns {
+			if uc.Name == c.Name {
+				u.PrimaryKey = append(u.PrimaryKey, uc)
+			}
+		}
+	}
+	for _, idx := range t.Indexes {
+		columns := make([]string, len(idx.Columns))
+		for i, c := range idx.Columns {
+			columns[i] = c.Name
+		}
+		u.AddIndex(idx.Name+"_unlogged", idx.Unique, columns)
+		index, _ := u.Index(idx.Name + "_unlogged")
+		index.Annotation, index.Expr, index.Concurrent = idx.Annotation, idx.Expr, idx.Concurrent
+	}
+	ant := &entsql.Annotation{}
+	if t.Annotation != nil {
+		*ant = *t.Annotation
+	}
+	ant.Table, ant.Unlogged = name, true
+	return u.SetAnnotation(ant)
+}
+
 // mayAddColumn adds the given column if it does not already exist in the table.
 func mayAddColumn(t *schema.Table, c *schema.Column) {
 	if !t.HasColumn(c.Name) {
@@ -725,6 +1112,15 @@ func deleteAction(e *Edge, c *schema.Column) schema.ReferenceOption {
 	return action
 }
 
+// deferrable sets the deferrability of the foreign-key from the annotation of the given edge.
+func deferrable(e *Edge, fk *schema.ForeignKey) *schema.ForeignKey {
+	if ant := e.EntSQL(); ant != nil {
+		fk.Deferrable = ant.Deferrable || ant.InitiallyDeferred
+		fk.InitiallyDeferred = ant.InitiallyDeferred
+	}
+	return fk
+}
+
 // SupportMigrate reports if the codegen supports schema migration.
 func (g *Graph) SupportMigrate() bool {
 	return g.Storage.SchemaMode.Support(Migrate)
@@ -759,6 +1155,166 @@ func (g *Graph) SchemaSnapshot() (string, error) {
 	return string(out), nil
 }
 
+// SchemaExportVersion is the version of the format that is written by the "schema/export"
+// feature. It is incremented only on breaking changes, and new attributes can be added
+// to the format without bumping it.
+const SchemaExportVersion = 1
+
+type (
+	// ExportSchema is the machine-readable representation of the graph schema
+	// that is written by the "schema/export" feature.
+	ExportSchema struct {
+		Version int           `json:"version"`
+		Package string        `json:"package"`
+		Types   []*ExportType `json:"types"`
+	}
+
+	// ExportType describes a type (entity) in the exported schema.
+	ExportType struct {
+		Name   string         `json:"name"`
+		Table  string         `json:"table,omitempty"`
+		ID     *ExportField   `json:"id,omitempty"`
+		Fields []*ExportField `json:"fields"`
+		Edges  []*ExportEdge  `json:"edges"`
+	}
+
+	// ExportField describes a field in the exported schema.
+	ExportField struct {
+		Name       string   `json:"name"`
+		Type       string   `json:"type"`
+		GoType     string   `json:"go_type"`
+		StorageKey string   `json:"storage_key,omitempty"`
+		Optional   bool     `json:"optional,omitempty"`
+		Nillable   bool     `json:"nillable,omitempty"`
+		Unique     bool     `json:"unique,omitempty"`
+		Immutable  bool     `json:"immutable,omitempty"`
+		Sensitive  bool     `json:"sensitive,omitempty"`
+		Default    bool     `json:"default,omitempty"`
+		Enums      []string `json:"enums,omitempty"`
+		Comment    string   `json:"comment,omitempty"`
+	}
+
+	// ExportEdge describes an edge in the exported schema.
+	ExportEdge struct {
+		Name     string `json:"name"`
+		Type     string `json:"type"`
+		Relation string `json:"relation"`
+		Unique   bool   `json:"unique,omitempty"`
+		Optional bool   `json:"optional,omitempty"`
+		Inverse  bool   `json:"inverse,omitempty"`
+		Ref      string `json:"ref,omitempty"`
+		Through  string `json:"through,omitempty"`
+		Comment  string `json:"comment,omitempty"`
+	}
+)
+
+// SchemaExport returns a JSON string that represents the graph schema in the
+// versioned format that is written by the "schema/export" feature.
+func (g *Graph) SchemaExport() (string, error) {
+	export := ExportSchema{
+		Version: SchemaExportVersion,
+		Package: g.Package,
+		Types:   make([]*ExportType, 0, len(g.Nodes)),
+	}
+	for _, n := range g.Nodes {
+		t := &ExportType{
+			Name:   n.Name,
+			Fields: make([]*ExportField, 0, len(n.Fields)),
+			Edges:  make([]*ExportEdge, 0, len(n.Edges)),
+		}
+		if g.Storage.SchemaMode.Support(Migrate) {
+			t.Table = n.Table()
+		}
+		if n.HasOneFieldID() {
+			t.ID = exportField(n.ID)
+		}
+		for _, f := range n.Fields {
+			t.Fields = append(t.Fields, exportField(f))
+		}
+		for _, e := range n.Edges {
+			ee := &ExportEdge{
+				Name:     e.Name,
+				Type:     e.Type.Name,
+				Relation: e.Rel.Type.String(),
+				Unique:   e.Unique,
+				Optional: e.Optional,
+				Inverse:  e.IsInverse(),
+				Comment:  e.Comment(),
+			}
+			if e.Ref != nil {
+				ee.Ref = e.Ref.Name
+			}
+			if e.Through != nil {
+				ee.Through = e.Through.Name
+			}
+			t.Edges = append(t.Edges, ee)
+		}
+		export.Types = append(export.Types, t)
+	}
+	out, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// exportField returns the exported representation of the given field.
+func exportField(f *Field) *ExportField {
+	return &ExportField{
+		Name:       f.Name,
+		Type:       exportType(f.Type.Type),
+		GoType:     f.Type.String(),
+		StorageKey: f.StorageKey(),
+		Optional:   f.Optional,
+		Nillable:   f.Nillable,
+		Unique:     f.Unique,
+		Immutable:  f.Immutable,
+		Sensitive:  f.Sensitive(),
+		Default:    f.Default,
+		Enums:      f.EnumValues(),
+		Comment:    f.Comment(),
+	}
+}
+
+// exportType returns the language-agnostic name of the given field type
+// (e.g. "json" or "uuid"), rather than its Go representation.
+func exportType(t field.Type) string {
+	return strings.ToLower(strings.TrimPrefix(t.ConstName(), "Type"))
+}
+
+// SchemaVersion returns a hash of the database schema (tables, columns, indexes and
+// foreign-keys) of the graph. It is used by the "sql/versioned-schema" feature for
+// detecting if the database schema is compatible with the generated code.
+func (g *Graph) SchemaVersion() (string, error) {
+	tables, err := g.Tables()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for _, t := range tables {
+		fmt.Fprintf(h, "table %q\n", t.Name)
+		for _, c := range t.Columns {
+			fmt.Fprintf(h, "column %q %s %d %t %t %t %v %v\n", c.Name, c.Type, c.Size, c.Nullable, c.Unique, c.Increment, c.Default, c.SchemaType)
+		}
+		for _, c := range t.PrimaryKey {
+			fmt.Fprintf(h, "primary key %q\n", c.Name)
+		}
+		for _, idx := range t.Indexes {
+			fmt.Fprintf(h, "index %q %t %q\n", idx.Name, idx.Unique, idx.Expr)
+			for _, c := range idx.Columns {
+				fmt.Fprintf(h, "index column %q\n", c.Name)
+			}
+		}
+		for _, fk := range t.ForeignKeys {
+			fmt.Fprintf(h, "foreign key %q %q\n", fk.Symbol, fk.RefTable.Name)
+			for i := range fk.Columns {
+				fmt.Fprintf(h, "foreign key column %q %q\n", fk.Columns[i].Name, fk.RefColumns[i].Name)
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func (g *Graph) typ(name string) (*Type, bool) {
 	if g.nodes == nil {
 		g.nodes = make(map[string]*Type, len(g.Nodes))
@@ -772,14 +1328,22 @@ func (g *Graph) typ(name string) (*Type, bool) {
 
 // templates returns the Template to execute on the Graph,
 // and a list of optional external templates if provided.
-func (g *Graph) templates() (*Template, []GraphTemplate) {
+func (g *Graph) templates() (*Template, []GraphTemplate, error) {
 	initTemplates()
 	var (
 		roots    = make(map[string]struct{})
 		helpers  = make(map[string]struct{})
 		external = make([]GraphTemplate, 0, len(g.Templates))
+		rootTs   = g.Templates
 	)
-	for _, rootT := range g.Templates {
+	if dir := g.TemplateDir; dir != "" {
+		t, err := NewTemplate("external").ParseDir(dir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse template dir %q: %w", dir, err)
+		}
+		rootTs = append(rootTs[:len(rootTs):len(rootTs)], t)
+	}
+	for _, rootT := range rootTs {
 		templates.Funcs(rootT.FuncMap)
 		for _, tmpl := range rootT.Templates() {
 			if parse.IsEmptyTree(tmpl.Root) {
@@ -820,7 +1384,7 @@ func (g *Graph) templates() (*Template, []GraphTemplate) {
 	for _, f := range g.Features {
 		external = append(external, f.GraphTemplates...)
 	}
-	return templates, external
+	return templates, external, nil
 }
 
 // ModuleInfo returns the entgo.io/ent version.
@@ -859,6 +1423,49 @@ func (c Config) FeatureEnabled(name string) (bool, error) {
 	return false, fmt.Errorf("unexpected feature name %q", name)
 }
 
+// PkgName returns the name of the generated package. For example:
+//
+//	{{ $pkg := $.Config.PkgName }}
+//
+func (c Config) PkgName() string {
+	if c.PackageName != "" {
+		return c.PackageName
+	}
+	return filepath.Base(c.Package)
+}
+
+// PkgAlias returns the alias that is used by the generated sub-packages for importing
+// the generated package, or an empty string if no alias is required. An alias is used
+// when a custom PackageName conflicts with the imports of the generated sub-packages.
+func (c Config) PkgAlias() string {
+	name := c.PackageName
+	if name == "" || name == filepath.Base(c.Package) {
+		return ""
+	}
+	if hasImport(name) {
+		return name + "gen"
+	}
+	for _, t := range GraphTemplates {
+		if dir := filepath.Dir(t.Format); dir != "." && filepath.Base(dir) == name {
+			return name + "gen"
+		}
+	}
+	return ""
+}
+
+// diagramFormat returns the format of the ER diagram that
+// is configured using the DiagramConfig annotation.
+func (c Config) diagramFormat() string {
+	d := DiagramConfig{}
+	if buf, err := json.Marshal(c.Annotations[d.Name()]); err == nil {
+		_ = json.Unmarshal(buf, &d)
+	}
+	if d.Format == "" {
+		return "mermaid"
+	}
+	return d.Format
+}
+
 // featureEnabled reports if the given feature-flag is enabled.
 func (c Config) featureEnabled(f Feature) bool {
 	for i := range c.Features {
@@ -937,6 +1544,30 @@ func cleanOldNodes(assets assets, target string) {
 	}
 }
 
+// cleanTestHelpers removes the testing.go file from the target directory, if it was generated
+// by ent. Files that do not start with the generated-code header were written by the user, and
+// are left as-is.
+func cleanTestHelpers(target string) error {
+	path := filepath.Join(target, "testing.go")
+	buf, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return err
+	}
+	for _, line := range strings.Split(string(buf), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+		if strings.HasPrefix(line, "// Code generated by ent") {
+			return os.Remove(path)
+		}
+	}
+	return nil
+}
+
 type assets struct {
 	dirs  map[string]struct{}
 	files map[string][]byte
@@ -971,9 +1602,12 @@ func (a assets) write() error {
 	return nil
 }
 
-// format runs "goimports" on all assets.
+// format runs "goimports" on all Go assets.
 func (a assets) format() error {
 	for path, content := range a.files {
+		if filepath.Ext(path) != ".go" {
+			continue
+		}
 		src, err := imports.Process(path, content, nil)
 		if err != nil {
 			return fmt.Errorf("format file %s: %w", path, err)
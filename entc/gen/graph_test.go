@@ -5,15 +5,21 @@
 package gen
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/entc/load"
+	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 
+	"github.com/emicklei/proto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -257,6 +263,275 @@ func TestNewGraphThroughDuplicates(t *testing.T) {
 	require.EqualError(t, err, `entc/gen: resolving edges: edge User.groups defined with Through("group_edges", T1.Type), but schema User already has an edge named group_edges`)
 }
 
+func TestNewGraphEdgeOrder(t *testing.T) {
+	schemas := func(o *edge.Order, unique bool) []*load.Schema {
+		return []*load.Schema{
+			{
+				Name:  "User",
+				Edges: []*load.Edge{{Name: "pets", Type: "Pet", Unique: unique, OrderBy: o}},
+			},
+			{
+				Name:   "Pet",
+				Fields: []*load.Field{{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}}},
+			},
+		}
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(&edge.Order{Field: "name", Direction: "desc"}, false)...)
+	require.NoError(t, err)
+	e := graph.Nodes[0].Edges[0]
+	require.Equal(t, graph.Nodes[1].Fields[0], e.OrderField())
+	require.Equal(t, "Desc", e.OrderFunc())
+	require.False(t, graph.Nodes[0].HasDefaultOrder())
+	require.True(t, graph.Nodes[1].HasDefaultOrder())
+
+	graph, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(&edge.Order{Field: "id", Direction: "ASC"}, false)...)
+	require.NoError(t, err)
+	require.Equal(t, graph.Nodes[1].ID, graph.Nodes[0].Edges[0].OrderField())
+	require.Equal(t, "Asc", graph.Nodes[0].Edges[0].OrderFunc())
+
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(&edge.Order{Field: "age", Direction: "ASC"}, false)...)
+	require.EqualError(t, err, `entc/gen: resolving edge orders: edge User.pets: OrderBy field "age" was not found in type Pet`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(&edge.Order{Field: "name", Direction: "UP"}, false)...)
+	require.EqualError(t, err, `entc/gen: resolving edge orders: edge User.pets: invalid OrderBy direction "UP", expect ASC or DESC`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(&edge.Order{Field: "name", Direction: "ASC"}, true)...)
+	require.EqualError(t, err, `entc/gen: resolving edge orders: edge User.pets: OrderBy is not supported on unique edges`)
+}
+
+func TestNewGraphEdgePreload(t *testing.T) {
+	schemas := func(unique bool, mode edge.PreloadMode) []*load.Schema {
+		return []*load.Schema{
+			{
+				Name:  "Pet",
+				Edges: []*load.Edge{{Name: "owner", Type: "User", Unique: unique, Preload: mode}},
+			},
+			{
+				Name:   "User",
+				Fields: []*load.Field{{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}}},
+			},
+		}
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(true, edge.InlineJSON)...)
+	require.NoError(t, err)
+	e := graph.Nodes[0].Edges[0]
+	require.True(t, e.InlineJSON())
+	require.Equal(t, "owner_json", e.InlineColumn())
+	require.Equal(t, []*Edge{e}, graph.Nodes[0].InlineEdges())
+	require.Empty(t, graph.Nodes[1].InlineEdges())
+
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(false, edge.InlineJSON)...)
+	require.EqualError(t, err, `entc/gen: resolving edge preloads: edge Pet.owner: Preload is supported only on unique edges`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(true, "lazy")...)
+	require.EqualError(t, err, `entc/gen: resolving edge preloads: edge Pet.owner: unknown Preload mode "lazy"`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[1]}, schemas(true, edge.InlineJSON)...)
+	require.EqualError(t, err, `entc/gen: resolving edge preloads: edge Pet.owner: Preload is not supported by the gremlin storage`)
+}
+
+func TestNewGraphEdgeCascade(t *testing.T) {
+	schemas := func(unique, softDelete bool, mode edge.CascadeMode) []*load.Schema {
+		post := &load.Schema{
+			Name:   "Post",
+			Fields: []*load.Field{{Name: "delete_time", Info: &field.TypeInfo{Type: field.TypeTime}, Optional: true, Nillable: true}},
+		}
+		if softDelete {
+			post.Annotations = map[string]interface{}{"SoftDelete": map[string]interface{}{"Field": "delete_time"}}
+		}
+		return []*load.Schema{
+			{
+				Name:        "User",
+				Fields:      []*load.Field{{Name: "delete_time", Info: &field.TypeInfo{Type: field.TypeTime}, Optional: true, Nillable: true}},
+				Edges:       []*load.Edge{{Name: "posts", Type: "Post", Unique: unique, Cascade: mode}},
+				Annotations: map[string]interface{}{"SoftDelete": map[string]interface{}{"Field": "delete_time"}},
+			},
+			post,
+		}
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(false, true, edge.SoftDelete)...)
+	require.NoError(t, err)
+	e := graph.Nodes[0].Edges[0]
+	require.True(t, e.CascadeSoftDelete())
+	require.Equal(t, []*Edge{e}, graph.Nodes[0].SoftDeleteCascadeEdges())
+	require.Empty(t, graph.Nodes[1].SoftDeleteCascadeEdges())
+
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(true, true, edge.SoftDelete)...)
+	require.EqualError(t, err, `entc/gen: resolving edge cascades: edge User.posts: Cascade(edge.SoftDelete) is supported only on O2M and O2O edges that hold the foreign-key in the edge table`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(false, false, edge.SoftDelete)...)
+	require.EqualError(t, err, `entc/gen: resolving edge cascades: edge User.posts: Cascade(edge.SoftDelete) requires User and Post to be defined with the SoftDelete mixin`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(false, true, "delete")...)
+	require.EqualError(t, err, `entc/gen: resolving edge cascades: edge User.posts: unknown Cascade mode "delete"`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[1]}, schemas(false, true, edge.SoftDelete)...)
+	require.EqualError(t, err, `entc/gen: resolving edge cascades: edge User.posts: Cascade is not supported by the gremlin storage`)
+}
+
+func TestNewGraphEdgeMapToField(t *testing.T) {
+	schemas := func(unique bool, name string) []*load.Schema {
+		return []*load.Schema{
+			{
+				Name:   "Pet",
+				Fields: []*load.Field{{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}}},
+				Edges:  []*load.Edge{{Name: "owner", Type: "User", Unique: unique, MapToField: name}},
+			},
+			{
+				Name: "User",
+			},
+		}
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(true, "owner_id")...)
+	require.NoError(t, err)
+	e := graph.Nodes[0].Edges[0]
+	require.Equal(t, "OwnerID", e.MappedField())
+	require.Equal(t, []*Edge{e}, graph.Nodes[0].MappedEdges())
+	require.Empty(t, graph.Nodes[1].MappedEdges())
+
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(false, "owner_id")...)
+	require.EqualError(t, err, `entc/gen: resolving edge field mappings: edge Pet.owner: MapToField is supported only on unique edges that hold the foreign-key`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(true, "name")...)
+	require.EqualError(t, err, `entc/gen: resolving edge field mappings: edge Pet.owner: MapToField name "name" conflicts with field "name"`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(true, "string")...)
+	require.EqualError(t, err, `entc/gen: resolving edge field mappings: edge Pet.owner: MapToField name "string" conflicts with method "String"`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(true, "query_owner")...)
+	require.EqualError(t, err, `entc/gen: resolving edge field mappings: edge Pet.owner: MapToField name "query_owner" conflicts with method "QueryOwner"`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(true, "edges")...)
+	require.EqualError(t, err, `entc/gen: resolving edge field mappings: edge Pet.owner: MapToField name "edges" conflicts with struct field "Edges"`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0], EntInterface: true}, schemas(true, "get_id")...)
+	require.EqualError(t, err, `entc/gen: resolving edge field mappings: edge Pet.owner: MapToField name "get_id" conflicts with method "GetID"`)
+	pets := schemas(true, "owner_id")
+	pets[0].Edges = append(pets[0].Edges, &load.Edge{Name: "friend", Type: "User", Unique: true, MapToField: "owner_id"})
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, pets...)
+	require.EqualError(t, err, `entc/gen: resolving edge field mappings: edge Pet.owner: MapToField name "owner_id" conflicts with the MapToField of edge "friend"`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[1]}, schemas(true, "owner_id")...)
+	require.EqualError(t, err, `entc/gen: resolving edge field mappings: edge Pet.owner: MapToField is not supported by the gremlin storage`)
+}
+
+func TestNewGraphEdgeJoinFilter(t *testing.T) {
+	schemas := func(through bool) []*load.Schema {
+		groups := &load.Edge{Name: "groups", Type: "Group", JoinFilter: &load.Position{Index: 0}}
+		if through {
+			groups.Through = &struct{ N, T string }{N: "memberships", T: "Membership"}
+		}
+		return []*load.Schema{
+			{
+				Name:  "User",
+				Edges: []*load.Edge{groups},
+			},
+			{
+				Name:  "Group",
+				Edges: []*load.Edge{{Name: "users", Type: "User", RefName: "groups", Inverse: true}},
+			},
+			{
+				Name: "Membership",
+				Fields: []*load.Field{
+					{Name: "status", Info: &field.TypeInfo{Type: field.TypeString}},
+					{Name: "user_id", Info: &field.TypeInfo{Type: field.TypeInt}},
+					{Name: "group_id", Info: &field.TypeInfo{Type: field.TypeInt}},
+				},
+				Edges: []*load.Edge{
+					{Name: "user", Type: "User", Unique: true, Required: true, Field: "user_id"},
+					{Name: "group", Type: "Group", Unique: true, Required: true, Field: "group_id"},
+				},
+			},
+		}
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(true)...)
+	require.NoError(t, err)
+	e := graph.Nodes[0].Edges[0]
+	require.Equal(t, []*Edge{e}, graph.Nodes[0].JoinFilterEdges())
+	require.Equal(t, "GroupsJoinFilter", e.JoinFilterName())
+	require.Empty(t, graph.Nodes[1].JoinFilterEdges())
+
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(false)...)
+	require.EqualError(t, err, `entc/gen: resolving edge join filters: edge User.groups: JoinFilter is supported only on edges with an edge schema (Through)`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[1]}, schemas(true)...)
+	require.EqualError(t, err, `entc/gen: resolving edge join filters: edge User.groups: JoinFilter is not supported by the gremlin storage`)
+}
+
+func TestNewGraphEdgeContext(t *testing.T) {
+	schemas := []*load.Schema{
+		{
+			Name:  "User",
+			Edges: []*load.Edge{{Name: "pets", Type: "Pet", Context: &load.Position{Index: 0}}},
+		},
+		{
+			Name:  "Pet",
+			Edges: []*load.Edge{{Name: "owner", Type: "User", RefName: "pets", Unique: true, Inverse: true}},
+		},
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas...)
+	require.NoError(t, err)
+	user, pet := graph.Nodes[0], graph.Nodes[1]
+	require.Equal(t, []*Edge{user.Edges[0]}, user.ContextEdges())
+	require.Equal(t, "PetsContext", user.Edges[0].ContextName())
+	require.Empty(t, pet.ContextEdges())
+	require.True(t, pet.HasEdgeContext())
+	require.False(t, user.HasEdgeContext())
+
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[1]}, schemas...)
+	require.EqualError(t, err, `entc/gen: resolving edge contexts: edge User.pets: WithContext is not supported by the gremlin storage`)
+}
+
+func TestNewGraphPolymorphicEdge(t *testing.T) {
+	schemas := func(e *load.Edge) []*load.Schema {
+		return []*load.Schema{
+			{Name: "Comment", Edges: []*load.Edge{e}},
+			{Name: "Post"},
+			{Name: "Video"},
+		}
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(&load.Edge{Name: "commentable", Inverse: true, Polymorphic: []string{"Post", "Video"}})...)
+	require.NoError(t, err)
+	c := graph.Nodes[0]
+	require.Empty(t, c.Edges)
+	require.Len(t, c.PolymorphicEdges, 1)
+	e := c.PolymorphicEdges[0]
+	require.Equal(t, "Commentable", e.StructField())
+	require.Equal(t, []*Type{graph.Nodes[1], graph.Nodes[2]}, e.Types)
+	require.Equal(t, []*Field{e.TypeField, e.IDField}, c.Fields)
+	require.Equal(t, "commentable_type", e.TypeField.Name)
+	require.Equal(t, []string{"Post", "Video"}, e.TypeField.EnumValues())
+	require.Equal(t, "CommentableTypePost", e.TypeField.EnumName("Post"))
+	require.Equal(t, "commentable_id", e.IDField.Name)
+	require.Equal(t, field.TypeInt, e.IDField.Type.Type)
+	require.True(t, e.TypeField.Optional)
+	require.Equal(t, []string{"commentable_type", "commentable_id"}, c.Indexes[0].Columns)
+	require.False(t, c.IsNoder())
+	require.True(t, graph.Nodes[1].IsNoder())
+	require.True(t, graph.Nodes[2].IsNoder())
+
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(&load.Edge{Name: "commentable", Inverse: true, Polymorphic: []string{"Post", "Image"}})...)
+	require.EqualError(t, err, `entc/gen: type "Image" does not exist for polymorphic edge Comment.commentable`)
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schemas(&load.Edge{Name: "commentable", Inverse: true, RefName: "comments", Polymorphic: []string{"Post", "Video"}})...)
+	require.EqualError(t, err, `entc/gen: polymorphic edge Comment.commentable cannot have a back-reference`)
+	videos := schemas(&load.Edge{Name: "commentable", Inverse: true, Polymorphic: []string{"Post", "Video"}})
+	videos[2].Fields = []*load.Field{{Name: "id", Info: &field.TypeInfo{Type: field.TypeString}}}
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, videos...)
+	require.EqualError(t, err, `entc/gen: types "Post" and "Video" of polymorphic edge Comment.commentable must have the same id type`)
+}
+
+func TestNewGraphPackageName(t *testing.T) {
+	schemas := []*load.Schema{{Name: "User"}}
+	graph, err := NewGraph(&Config{Package: "github.com/org/myapp/internal", PackageName: "myapp", Storage: drivers[0]}, schemas...)
+	require.NoError(t, err)
+	require.Equal(t, "myapp", graph.PkgName())
+	require.Empty(t, graph.PkgAlias())
+
+	graph, err = NewGraph(&Config{Package: "github.com/org/app/ent", Storage: drivers[0]}, schemas...)
+	require.NoError(t, err)
+	require.Equal(t, "ent", graph.PkgName())
+	require.Empty(t, graph.PkgAlias())
+
+	// Load the imports of the templates.
+	initTemplates()
+	for name, alias := range map[string]string{"sql": "sqlgen", "hook": "hookgen", "context": "contextgen"} {
+		graph, err = NewGraph(&Config{Package: "github.com/org/app", PackageName: name, Storage: drivers[0]}, schemas...)
+		require.NoError(t, err)
+		require.Equal(t, alias, graph.PkgAlias())
+	}
+
+	_, err = NewGraph(&Config{Package: "github.com/org/app", PackageName: "my-app", Storage: drivers[0]}, schemas...)
+	require.EqualError(t, err, `entc/gen: resolving package name: invalid package name "my-app"`)
+	_, err = NewGraph(&Config{Package: "github.com/org/app", PackageName: "user", Storage: drivers[0]}, schemas...)
+	require.EqualError(t, err, `entc/gen: resolving package name: package name "user" conflicts with the package of type "User"`)
+}
+
 func TestRelation(t *testing.T) {
 	require := require.New(t)
 	_, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, T1)
@@ -343,6 +618,503 @@ func TestFKColumns(t *testing.T) {
 	}
 }
 
+func TestJoinTable(t *testing.T) {
+	require := require.New(t)
+	user := &load.Schema{
+		Name: "User",
+		Edges: []*load.Edge{
+			{Name: "tags", Type: "Tag", Annotations: map[string]interface{}{
+				"EntSQL": entsql.JoinTable("user_labels", "user_id", "label_id"),
+			}},
+		},
+	}
+	tag := &load.Schema{
+		Name: "Tag",
+		Edges: []*load.Edge{
+			{Name: "users", Type: "User", RefName: "tags", Inverse: true},
+		},
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, user, tag)
+	require.NoError(err)
+	for _, e := range []*Edge{graph.Nodes[0].Edges[0], graph.Nodes[1].Edges[0]} {
+		require.Equal("user_labels", e.Rel.Table)
+		require.Equal([]string{"user_id", "label_id"}, e.Rel.Columns)
+	}
+	tables, err := graph.Tables()
+	require.NoError(err)
+	require.Len(tables, 3)
+	require.Equal("user_labels", tables[2].Name)
+	require.Equal("user_id", tables[2].Columns[0].Name)
+	require.Equal("label_id", tables[2].Columns[1].Name)
+
+	user.Edges[0].StorageKey = &edge.StorageKey{Table: "user_tags"}
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, user, tag)
+	require.Error(err)
+}
+
+func TestDeferrableForeignKey(t *testing.T) {
+	require := require.New(t)
+	node := &load.Schema{
+		Name: "Node",
+		Edges: []*load.Edge{
+			{Name: "parent", Type: "Node", Unique: true, Annotations: map[string]interface{}{
+				"EntSQL": entsql.InitiallyDeferred(true),
+			}},
+			{Name: "owner", Type: "User", Unique: true, Annotations: map[string]interface{}{
+				"EntSQL": entsql.Deferrable(true),
+			}},
+		},
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, node, &load.Schema{Name: "User"})
+	require.NoError(err)
+	tables, err := graph.Tables()
+	require.NoError(err)
+	fks := tables[0].ForeignKeys
+	require.Len(fks, 2)
+	require.True(fks[0].Deferrable)
+	require.True(fks[0].InitiallyDeferred)
+	require.True(fks[1].Deferrable)
+	require.False(fks[1].InitiallyDeferred)
+}
+
+func TestGraph_WordsCheck(t *testing.T) {
+	require := require.New(t)
+	post := &load.Schema{
+		Name: "Post",
+		Fields: []*load.Field{
+			{Name: "excerpt", Info: &field.TypeInfo{Type: field.TypeString}, MaxWords: 50},
+		},
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, post)
+	require.NoError(err)
+	tables, err := graph.Tables()
+	require.NoError(err)
+	require.Equal(50, tables[0].Columns[1].MaxWords)
+	require.Nil(tables[0].Annotation, "checks are added by the dialect migrators")
+}
+
+func TestGraph_SchemaVersion(t *testing.T) {
+	require := require.New(t)
+	version := func(size int64) string {
+		graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, &load.Schema{
+			Name: "User",
+			Fields: []*load.Field{
+				{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}, Size: &size},
+			},
+		})
+		require.NoError(err)
+		v, err := graph.SchemaVersion()
+		require.NoError(err)
+		return v
+	}
+	v1, v2 := version(10), version(10)
+	require.Len(v1, 64)
+	require.Equal(v1, v2, "version should be stable")
+	require.NotEqual(v1, version(20), "version should change on column changes")
+}
+
+func TestGraph_TemplateDir(t *testing.T) {
+	require := require.New(t)
+	target := t.TempDir()
+	dir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(dir, "external.tmpl"), []byte(`{{ define "external" }}package external{{ end }}`), 0644))
+	graph, err := NewGraph(&Config{
+		Package:     "entc/gen",
+		Target:      target,
+		Storage:     drivers[0],
+		TemplateDir: dir,
+		IDType:      &field.TypeInfo{Type: field.TypeInt},
+	}, &load.Schema{Name: "T1"})
+	require.NoError(err)
+	require.NoError(graph.Gen())
+	_, err = os.Stat(filepath.Join(target, "external.go"))
+	require.NoError(err)
+
+	graph.TemplateDir = filepath.Join(dir, "missing")
+	require.Error(graph.Gen())
+}
+
+func TestGraph_Features(t *testing.T) {
+	userPets := []*load.Schema{
+		{
+			Name: "User",
+			Fields: []*load.Field{
+				{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}, Unique: true},
+				{Name: "tags", Info: &field.TypeInfo{Type: field.TypeJSON, Ident: "[]string", RType: &field.RType{Kind: reflect.Slice, Ident: "[]string"}}, Optional: true},
+			},
+			Edges: []*load.Edge{
+				{Name: "pets", Type: "Pet"},
+			},
+		},
+		{
+			Name: "Pet",
+			Edges: []*load.Edge{
+				{Name: "owner", Type: "User", RefName: "pets", Unique: true, Inverse: true},
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		schemas     []*load.Schema
+		features    []Feature
+		annotations Annotations
+		// contains and notContains hold the expected (and unexpected)
+		// contents of the generated files, keyed by their path.
+		contains    map[string][]string
+		notContains map[string][]string
+		// check is an optional function for checking the generated files.
+		check func(t *testing.T, target string)
+		// keep holds files that were not generated by ent,
+		// and should not be removed on cleanup.
+		keep []string
+	}{
+		{
+			name:        "diagram/mermaid",
+			schemas:     userPets,
+			features:    []Feature{FeatureDiagram},
+			annotations: Annotations{"Diagram": DiagramConfig{Format: "mermaid"}},
+			contains: map[string][]string{
+				"docs/schema.mmd": {
+					"    User {\n        int id PK\n        string name UK\n        string[] tags \"optional\"\n    }",
+					`    User |o--o{ Pet : "pets/owner"`,
+				},
+			},
+		},
+		{
+			name:        "diagram/plantuml",
+			schemas:     userPets,
+			features:    []Feature{FeatureDiagram},
+			annotations: Annotations{"Diagram": DiagramConfig{Format: "plantuml"}},
+			contains: map[string][]string{
+				"docs/schema.puml": {
+					"entity User {\n  * id : int <<PK>>\n  --\n  * name : string <<UK>>\n  tags : []string\n}",
+					"User |o--o{ Pet : pets/owner",
+				},
+			},
+		},
+		{
+			name:     "schema/export",
+			schemas:  userPets,
+			features: []Feature{FeatureSchemaExport},
+			contains: map[string][]string{
+				"schema.json": {`"version": 1`},
+			},
+			check: func(t *testing.T, target string) {
+				buf, err := os.ReadFile(filepath.Join(target, "schema.json"))
+				require.NoError(t, err)
+				var export ExportSchema
+				require.NoError(t, json.Unmarshal(buf, &export))
+				require.Equal(t, SchemaExportVersion, export.Version)
+				require.Len(t, export.Types, 2)
+				user, pet := export.Types[0], export.Types[1]
+				require.Equal(t, "User", user.Name)
+				require.Equal(t, "users", user.Table)
+				require.Equal(t, &ExportField{Name: "id", Type: "int", GoType: "int", StorageKey: "id"}, user.ID)
+				require.Equal(t, []*ExportField{
+					{Name: "name", Type: "string", GoType: "string", StorageKey: "name", Unique: true},
+					{Name: "tags", Type: "json", GoType: "[]string", StorageKey: "tags", Optional: true},
+				}, user.Fields)
+				require.Equal(t, []*ExportEdge{{Name: "pets", Type: "Pet", Relation: "O2M", Optional: true, Ref: "owner"}}, user.Edges)
+				require.Equal(t, []*ExportEdge{{Name: "owner", Type: "User", Relation: "M2O", Unique: true, Optional: true, Inverse: true, Ref: "pets"}}, pet.Edges)
+			},
+		},
+		{
+			name:        "cache/repo",
+			schemas:     []*load.Schema{{Name: "User"}, {Name: "Role"}},
+			features:    []Feature{FeatureCachingRepository},
+			annotations: Annotations{"CachingRepository": CachingRepositoryConfig{TTL: time.Minute}},
+			contains: map[string][]string{
+				"cache/repo.go": {
+					"const DefaultRepoTTL = time.Duration(60000000000)",
+					"func NewCachedUserRepo(client *gen.Client, ttl time.Duration) *CachedUserRepo",
+					"func (r *CachedRoleRepo) Get(ctx context.Context, id int) (*gen.Role, error)",
+				},
+			},
+		},
+		{
+			name: "bench",
+			schemas: []*load.Schema{
+				{
+					Name: "User",
+					Fields: []*load.Field{
+						{Name: "email", Info: &field.TypeInfo{Type: field.TypeString}, Unique: true},
+						{Name: "nickname", Info: &field.TypeInfo{Type: field.TypeString}, Unique: true, Optional: true, Nillable: true},
+						{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+					},
+				},
+			},
+			features: []Feature{FeatureQueryBenchmark},
+			contains: map[string][]string{
+				"bench_test.go": {`drv.Query(ctx, "EXPLAIN ANALYZE "+query, args, rows)`},
+				"user_bench_test.go": {
+					"func BenchmarkUserQueryByID(b *testing.B)",
+					"func BenchmarkUserQueryByEmail(b *testing.B)",
+					"func BenchmarkUserQueryList(b *testing.B)",
+				},
+			},
+			notContains: map[string][]string{
+				"user_bench_test.go": {"BenchmarkUserQueryByNickname", "BenchmarkUserQueryByName"},
+			},
+			keep: []string{"custom_bench_test.go"},
+		},
+		{
+			name: "graph",
+			schemas: []*load.Schema{
+				{
+					Name: "User",
+					Edges: []*load.Edge{
+						{Name: "pets", Type: "Pet"},
+						{Name: "friends", Type: "User"},
+					},
+				},
+				{
+					Name: "Pet",
+					Edges: []*load.Edge{
+						{Name: "owner", Type: "User", RefName: "pets", Unique: true, Inverse: true},
+					},
+				},
+				{Name: "Group"},
+			},
+			features: []Feature{FeatureGraphRepository},
+			contains: map[string][]string{
+				"graph/graph.go": {
+					"func From(node interface{}) *Traversal",
+					"t.value = v.QueryPets().Where(petFilters(filters)...)",
+					"t.value = v.QueryOwner().Where(userFilters(filters)...)",
+					"func (t *Traversal) CollectGroups(ctx context.Context) ([]*gen.Group, error)",
+				},
+			},
+		},
+		{
+			name: "rust/serde",
+			schemas: []*load.Schema{
+				{
+					Name: "User",
+					Fields: []*load.Field{
+						{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+						{Name: "age", Info: &field.TypeInfo{Type: field.TypeUint8}, Optional: true},
+						{Name: "type", Info: &field.TypeInfo{Type: field.TypeEnum}, Enums: []struct{ N, V string }{{N: "admin", V: "admin"}, {N: "user", V: "user"}}},
+						{Name: "password", Info: &field.TypeInfo{Type: field.TypeString}, Sensitive: true},
+					},
+				},
+			},
+			features: []Feature{FeatureRustSerde},
+			contains: map[string][]string{
+				"models.rs": {
+					"pub enum UserType {\n    #[default]\n    #[serde(rename = \"admin\")]\n    Admin,",
+					"pub struct User {\n    #[serde(rename = \"id\", default)]\n    pub id: i64,",
+					`pub age: Option<u8>,`,
+					`pub r#type: UserType,`,
+				},
+			},
+			notContains: map[string][]string{
+				"models.rs": {"password"},
+			},
+		},
+		{
+			name: "federation",
+			schemas: []*load.Schema{
+				{Name: "User"},
+				{
+					Name:   "Pet",
+					Fields: []*load.Field{{Name: "id", Info: &field.TypeInfo{Type: field.TypeString}}},
+				},
+				{
+					Name: "Card",
+					Fields: []*load.Field{{Name: "id", Info: &field.TypeInfo{
+						Type:    field.TypeUUID,
+						Ident:   "uuid.UUID",
+						PkgPath: "github.com/google/uuid",
+						RType:   &field.RType{Name: "UUID", Ident: "uuid.UUID", Kind: reflect.Array, PkgPath: "github.com/google/uuid"},
+					}}},
+				},
+			},
+			features: []Feature{FeatureFederation},
+			contains: map[string][]string{
+				"federation/federation.go": {
+					"func (r *Resolver) Entities(ctx context.Context, representations []Representation) ([]interface{}, error)",
+					"func (r *UserResolver) ResolveEntity(ctx context.Context, rep Representation) (*gen.User, error)",
+					"n, err := strconv.ParseInt(s, 10, 64)",
+					"func (r *PetResolver) ResolveEntity(ctx context.Context, rep Representation) (*gen.Pet, error)",
+				},
+			},
+			notContains: map[string][]string{
+				"federation/federation.go": {"CardResolver"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := filepath.Join(t.TempDir(), "ent")
+			graph, err := NewGraph(&Config{
+				Package:     "entc/gen",
+				Target:      target,
+				Storage:     drivers[0],
+				IDType:      &field.TypeInfo{Type: field.TypeInt},
+				Features:    tt.features,
+				Annotations: tt.annotations,
+			}, tt.schemas...)
+			require.NoError(t, err)
+			require.NoError(t, graph.Gen())
+			for _, name := range tt.keep {
+				require.NoError(t, os.WriteFile(filepath.Join(target, name), []byte("package gen"), 0644))
+			}
+			for name, expected := range tt.contains {
+				buf, err := os.ReadFile(filepath.Join(target, name))
+				require.NoError(t, err)
+				for _, s := range expected {
+					require.Contains(t, string(buf), s)
+				}
+			}
+			for name, unexpected := range tt.notContains {
+				buf, err := os.ReadFile(filepath.Join(target, name))
+				require.NoError(t, err)
+				for _, s := range unexpected {
+					require.NotContains(t, string(buf), s)
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, target)
+			}
+			// Generated files are removed when the features are disabled.
+			graph.Features = nil
+			require.NoError(t, graph.Gen())
+			for name := range tt.contains {
+				_, err := os.Stat(filepath.Join(target, name))
+				require.True(t, os.IsNotExist(err), name)
+			}
+			for _, name := range tt.keep {
+				_, err := os.Stat(filepath.Join(target, name))
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGraph_UnloggedTable(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "ent")
+	schemas := []*load.Schema{
+		{
+			Name: "User",
+			Fields: []*load.Field{
+				{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+			},
+			Edges: []*load.Edge{
+				{Name: "pets", Type: "Pet"},
+			},
+			Indexes: []*load.Index{
+				{Fields: []string{"name"}, Unique: true},
+			},
+		},
+		{
+			Name: "Pet",
+			Edges: []*load.Edge{
+				{Name: "owner", Type: "User", RefName: "pets", Unique: true, Inverse: true},
+			},
+		},
+	}
+	graph, err := NewGraph(&Config{
+		Package:  "entc/gen",
+		Target:   target,
+		Storage:  drivers[0],
+		IDType:   &field.TypeInfo{Type: field.TypeInt},
+		Features: []Feature{FeatureUnloggedTable},
+	}, schemas...)
+	require.NoError(t, err)
+	tables, err := graph.Tables()
+	require.NoError(t, err)
+	require.Len(t, tables, 4)
+	users, pets := tables[2], tables[3]
+	require.Equal(t, "users_unlogged", users.Name)
+	require.Equal(t, &entsql.Annotation{Table: "users_unlogged", Unlogged: true}, users.Annotation)
+	require.Len(t, users.PrimaryKey, 1)
+	require.Equal(t, "id", users.PrimaryKey[0].Name)
+	require.NotSame(t, tables[0].PrimaryKey[0], users.PrimaryKey[0])
+	require.Len(t, users.Indexes, 1)
+	require.Equal(t, "user_name_unlogged", users.Indexes[0].Name)
+	require.Equal(t, "pets_unlogged", pets.Name)
+	require.True(t, pets.HasColumn("user_pets"))
+	require.Len(t, tables[1].ForeignKeys, 1)
+	require.Empty(t, pets.ForeignKeys)
+
+	require.NoError(t, graph.Gen())
+	_, err = os.Stat(filepath.Join(target, "unlogged.go"))
+	require.NoError(t, err)
+
+	graph.Features = nil
+	require.NoError(t, graph.Gen())
+	_, err = os.Stat(filepath.Join(target, "unlogged.go"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestGraph_GRPCGateway(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "ent")
+	graph, err := NewGraph(&Config{
+		Package:  "entc/gen",
+		Target:   target,
+		Storage:  drivers[0],
+		IDType:   &field.TypeInfo{Type: field.TypeInt},
+		Features: []Feature{FeatureGRPCGateway},
+	}, &load.Schema{
+		Name: "User",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "password", Info: &field.TypeInfo{Type: field.TypeString}, Sensitive: true},
+			{Name: "age", Info: &field.TypeInfo{Type: field.TypeInt8}, Optional: true},
+			{Name: "created_at", Info: &field.TypeInfo{Type: field.TypeTime}},
+			{Name: "role", Info: &field.TypeInfo{Type: field.TypeEnum}, Enums: []struct{ N, V string }{{N: "admin", V: "admin"}}},
+			{Name: "meta", Info: &field.TypeInfo{Type: field.TypeJSON, Ident: "map[string]string"}, Optional: true},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, graph.Gen())
+	f, err := os.Open(filepath.Join(target, "proto", "entpb", "entpb.proto"))
+	require.NoError(t, err)
+	defer f.Close()
+	def, err := proto.NewParser(f).Parse()
+	require.NoError(t, err)
+
+	var (
+		fields []string
+		routes = make(map[string]string)
+	)
+	proto.Walk(def,
+		proto.WithMessage(func(m *proto.Message) {
+			if m.Name != "User" {
+				return
+			}
+			for _, e := range m.Elements {
+				f := e.(*proto.NormalField)
+				fields = append(fields, fmt.Sprintf("%v %s %s = %d", f.Optional, f.Type, f.Name, f.Sequence))
+			}
+		}),
+		proto.WithRPC(func(r *proto.RPC) {
+			for _, e := range r.Elements {
+				o := e.(*proto.Option)
+				require.Equal(t, "(google.api.http)", o.Name)
+				for _, l := range o.Constant.OrderedMap {
+					routes[r.Name] += fmt.Sprintf("%s:%s;", l.Name, l.Source)
+				}
+			}
+		}),
+	)
+	require.Equal(t, []string{
+		"false int64 id = 1",
+		"false string name = 2",
+		"true int32 age = 4",
+		"false google.protobuf.Timestamp created_at = 5",
+		"false string role = 6",
+		"true bytes meta = 7",
+	}, fields, "sensitive fields should be omitted")
+	require.Equal(t, map[string]string{
+		"Create": "post:/users;body:user;",
+		"Get":    "get:/users/{id};",
+		"Update": "patch:/users/{user.id};body:user;",
+		"Delete": "delete:/users/{id};",
+		"List":   "get:/users;",
+	}, routes)
+}
+
 func TestGraph_Gen(t *testing.T) {
 	require := require.New(t)
 	target := filepath.Join(os.TempDir(), "ent")
@@ -399,6 +1171,22 @@ func TestGraph_Gen(t *testing.T) {
 	require.NoError(err)
 	_, err = os.Stat(filepath.Join(target, "internal", "schemaconfig.go"))
 	require.NoError(err)
+	_, err = os.Stat(filepath.Join(target, "dlq.go"))
+	require.NoError(err)
+	_, err = os.Stat(filepath.Join(target, "proto", "entpb", "entpb.proto"))
+	require.NoError(err)
+	_, err = os.Stat(filepath.Join(target, "registry", "registry.go"))
+	require.NoError(err)
+	_, err = os.Stat(filepath.Join(target, "dumper", "dumper.go"))
+	require.NoError(err)
+	_, err = os.Stat(filepath.Join(target, "middleware", "middleware.go"))
+	require.NoError(err)
+	_, err = os.Stat(filepath.Join(target, "docs", "schema.mmd"))
+	require.NoError(err)
+	_, err = os.Stat(filepath.Join(target, "docs", "schema.puml"))
+	require.True(os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(target, "changelog.go"))
+	require.NoError(err)
 	// Rerun codegen with only one feature-flag.
 	graph.Features = []Feature{FeatureSnapshot}
 	require.NoError(graph.Gen())
@@ -407,6 +1195,20 @@ func TestGraph_Gen(t *testing.T) {
 	require.NoError(err)
 	_, err = os.Stat(filepath.Join(target, "internal", "schemaconfig.go"))
 	require.True(os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(target, "dlq.go"))
+	require.True(os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(target, "proto"))
+	require.True(os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(target, "registry"))
+	require.True(os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(target, "dumper"))
+	require.True(os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(target, "middleware"))
+	require.True(os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(target, "docs", "schema.mmd"))
+	require.True(os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(target, "changelog.go"))
+	require.True(os.IsNotExist(err))
 	// Rerun codegen without any feature-flags.
 	graph.Features = nil
 	require.NoError(graph.Gen())
@@ -451,6 +1253,81 @@ func ensureStructTag(name string) Hook {
 	}
 }
 
+func TestGraph_InjectTxIntoContext(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "ent")
+	schemas := []*load.Schema{{Name: "T1"}}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Target: target, Storage: drivers[0], IDType: &field.TypeInfo{Type: field.TypeInt}, InjectTxIntoContext: true}, schemas...)
+	require.NoError(t, err)
+	require.NoError(t, graph.Gen())
+	buf, err := os.ReadFile(filepath.Join(target, "tx.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(buf), "func (c config) txContext(ctx context.Context) context.Context")
+	for _, name := range []string{"t1_create.go", "t1_update.go", "t1_delete.go"} {
+		buf, err := os.ReadFile(filepath.Join(target, name))
+		require.NoError(t, err)
+		require.Contains(t, string(buf), ".txContext(ctx)")
+	}
+
+	graph.InjectTxIntoContext = false
+	require.NoError(t, graph.Gen())
+	buf, err = os.ReadFile(filepath.Join(target, "tx.go"))
+	require.NoError(t, err)
+	require.NotContains(t, string(buf), "txContext")
+}
+
+func TestGraph_GenerateTestHelpers(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "ent")
+	schemas := []*load.Schema{{Name: "T1"}}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Target: target, Storage: drivers[0], IDType: &field.TypeInfo{Type: field.TypeInt}, GenerateTestHelpers: true}, schemas...)
+	require.NoError(t, err)
+	require.NoError(t, graph.Gen())
+	buf, err := os.ReadFile(filepath.Join(target, "testing.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(buf), "//go:build testonly")
+	require.Contains(t, string(buf), "package gen")
+	require.Contains(t, string(buf), "func NewTestClient(t testing.TB, opts ...Option) *Client")
+
+	graph.GenerateTestHelpers = false
+	require.NoError(t, graph.Gen())
+	_, err = os.Stat(filepath.Join(target, "testing.go"))
+	require.True(t, os.IsNotExist(err))
+
+	// Files that were not generated by ent are not removed.
+	user := []byte("package gen\n\n// Written by the user.\nfunc helper() {}\n")
+	require.NoError(t, os.WriteFile(filepath.Join(target, "testing.go"), user, 0644))
+	require.NoError(t, graph.Gen())
+	buf, err = os.ReadFile(filepath.Join(target, "testing.go"))
+	require.NoError(t, err)
+	require.Equal(t, user, buf)
+}
+
+func TestGraph_EntInterface(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "ent")
+	schemas := []*load.Schema{
+		{
+			Name:   "T1",
+			Fields: []*load.Field{{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}}},
+		},
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Target: target, Storage: drivers[0], IDType: &field.TypeInfo{Type: field.TypeInt}, EntInterface: true}, schemas...)
+	require.NoError(t, err)
+	require.NoError(t, graph.Gen())
+	buf, err := os.ReadFile(filepath.Join(target, "ent.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(buf), "type Entity interface {")
+	buf, err = os.ReadFile(filepath.Join(target, "t1.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(buf), "func (t *T1) GetID() interface{}")
+	require.Contains(t, string(buf), "return t1.Label")
+	require.Contains(t, string(buf), "case t1.FieldName:")
+
+	graph.EntInterface = false
+	require.NoError(t, graph.Gen())
+	buf, err = os.ReadFile(filepath.Join(target, "ent.go"))
+	require.NoError(t, err)
+	require.NotContains(t, string(buf), "Entity")
+}
+
 func TestGraph_Hooks(t *testing.T) {
 	require := require.New(t)
 	graph, err := NewGraph(&Config{
@@ -474,6 +1351,36 @@ func TestGraph_Hooks(t *testing.T) {
 	require.EqualError(graph.Gen(), `struct tag "yaml" is missing for field T1.age`)
 }
 
+func TestGraph_PostGenerateHook(t *testing.T) {
+	require := require.New(t)
+	target := filepath.Join(t.TempDir(), "ent")
+	var dirs []string
+	graph, err := NewGraph(&Config{
+		Package: "entc/gen",
+		Target:  target,
+		Storage: drivers[0],
+		IDType:  &field.TypeInfo{Type: field.TypeInt},
+		PostGenerateHook: func(dir string) error {
+			_, err := os.Stat(filepath.Join(dir, "client.go"))
+			dirs = append(dirs, dir)
+			return err
+		},
+	}, &load.Schema{Name: "T1"})
+	require.NoError(err)
+	require.NoError(graph.Gen())
+	require.Equal([]string{target}, dirs)
+
+	graph.PostGenerateHook = func(string) error { return errors.New("vet failed") }
+	require.EqualError(graph.Gen(), "post-generate hook: vet failed")
+
+	// Hook is not called in case the codegen failed.
+	graph.Hooks = []Hook{func(Generator) Generator {
+		return GenerateFunc(func(*Graph) error { return errors.New("codegen failed") })
+	}}
+	graph.PostGenerateHook = func(string) error { return errors.New("unexpected call") }
+	require.EqualError(graph.Gen(), "codegen failed")
+}
+
 func TestDependencyAnnotation_Build(t *testing.T) {
 	tests := []struct {
 		typ   *field.TypeInfo
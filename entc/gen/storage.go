@@ -77,6 +77,7 @@ var drivers = []*Storage{
 			"entgo.io/ent/dialect/gremlin/graph/dsl/g",
 			"entgo.io/ent/dialect/gremlin/graph/dsl/p",
 			"entgo.io/ent/dialect/gremlin/encoding/graphson",
+			"entgo.io/ent/schema/field",
 		},
 		SchemaMode: Unique,
 		OpCode:     opCodes(gremlinCode[:]),
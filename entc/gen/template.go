@@ -19,6 +19,7 @@ import (
 	"strings"
 	"text/template"
 	"text/template/parse"
+	"time"
 
 	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/field"
@@ -29,6 +30,7 @@ type (
 	// each Type object of the graph.
 	TypeTemplate struct {
 		Name           string             // template name.
+		Skip           func(*Graph) bool  // skip condition (storage constraints or gated by a feature-flag).
 		Format         func(*Type) string // file name format.
 		ExtendPatterns []string           // extend patterns.
 	}
@@ -88,6 +90,13 @@ var (
 				"meta/additional/*",
 			},
 		},
+		{
+			Name:   "bench",
+			Format: pkgf("%s_bench_test.go"),
+			Skip: func(g *Graph) bool {
+				return !g.featureEnabled(FeatureQueryBenchmark) || g.Storage.Name != "sql"
+			},
+		},
 	}
 	// GraphTemplates holds the templates applied on the graph.
 	GraphTemplates = []GraphTemplate{
@@ -154,6 +163,83 @@ var (
 				return !g.featureEnabled(FeatureEntQL)
 			},
 		},
+		{
+			Name:   "dd",
+			Format: "dd/dd.go",
+			Skip: func(g *Graph) bool {
+				return !g.featureEnabled(FeatureDatadog)
+			},
+		},
+		{
+			Name:   "cache",
+			Format: "cache/cache.go",
+			Skip: func(g *Graph) bool {
+				return !g.featureEnabled(FeatureCache)
+			},
+		},
+		{
+			Name:   "dlq",
+			Format: "dlq.go",
+			Skip: func(g *Graph) bool {
+				return !g.featureEnabled(FeatureDeadLetterQueue)
+			},
+		},
+		{
+			Name:   "grpcgateway",
+			Format: "proto/entpb/entpb.proto",
+			Skip: func(g *Graph) bool {
+				return !g.featureEnabled(FeatureGRPCGateway)
+			},
+		},
+		{
+			Name:   "registry",
+			Format: "registry/registry.go",
+			Skip: func(g *Graph) bool {
+				return !g.featureEnabled(FeatureSchemaRegistry)
+			},
+		},
+		{
+			Name:   "changelog",
+			Format: "changelog.go",
+			Skip: func(g *Graph) bool {
+				return !g.featureEnabled(FeatureJSONBChangelog) || g.Storage.Name != "sql"
+			},
+		},
+		{
+			Name:   "unlogged",
+			Format: "unlogged.go",
+			Skip: func(g *Graph) bool {
+				return !g.featureEnabled(FeatureUnloggedTable) || g.Storage.Name != "sql"
+			},
+		},
+		{
+			Name:   "dumper",
+			Format: "dumper/dumper.go",
+			Skip: func(g *Graph) bool {
+				return !g.featureEnabled(FeatureDumper) || g.Storage.Name != "sql"
+			},
+		},
+		{
+			Name:   "middleware",
+			Format: "middleware/middleware.go",
+			Skip: func(g *Graph) bool {
+				return !g.featureEnabled(FeatureValidationMiddleware)
+			},
+		},
+		{
+			Name:   "diagram/mermaid",
+			Format: "docs/schema.mmd",
+			Skip: func(g *Graph) bool {
+				return !g.featureEnabled(FeatureDiagram) || g.diagramFormat() != "mermaid"
+			},
+		},
+		{
+			Name:   "diagram/plantuml",
+			Format: "docs/schema.puml",
+			Skip: func(g *Graph) bool {
+				return !g.featureEnabled(FeatureDiagram) || g.diagramFormat() != "plantuml"
+			},
+		},
 		{
 			Name:   "runtime/ent",
 			Format: "runtime.go",
@@ -162,6 +248,13 @@ var (
 			Name:   "enttest",
 			Format: "enttest/enttest.go",
 		},
+		{
+			Name:   "testing",
+			Format: "testing.go",
+			Skip: func(g *Graph) bool {
+				return !g.GenerateTestHelpers
+			},
+		},
 		{
 			Name:   "runtime/pkg",
 			Format: "runtime/runtime.go",
@@ -419,6 +512,88 @@ func (d *Dependency) defaultName() (string, error) {
 	return pascal(pkg) + pascal(name), nil
 }
 
+// CacheConfig is a codegen annotation for configuring the cache package
+// that is generated by the "cache" feature.
+type CacheConfig struct {
+	// Backend defines the cache backend to generate an adapter for.
+	// One of "memory", "redis" or "memcached". An in-memory backend
+	// is always generated.
+	Backend string
+}
+
+// Name describes the annotation name.
+func (CacheConfig) Name() string {
+	return "Cache"
+}
+
+// CachingRepositoryConfig is a codegen annotation for configuring the
+// cached repositories that are generated by the "cache/repo" feature.
+type CachingRepositoryConfig struct {
+	// TTL is the default TTL of the cached entries. A zero
+	// value means the entries do not expire.
+	TTL time.Duration
+}
+
+// Name describes the annotation name.
+func (CachingRepositoryConfig) Name() string {
+	return "CachingRepository"
+}
+
+// DiagramConfig is a codegen annotation for configuring the ER diagram
+// that is generated by the "diagram" feature.
+type DiagramConfig struct {
+	// Format of the diagram. One of "mermaid" (the default),
+	// or "plantuml".
+	Format string
+}
+
+// Name describes the annotation name.
+func (DiagramConfig) Name() string {
+	return "Diagram"
+}
+
+type (
+	// ContextValues wraps a list of typed context values as codegen
+	// annotation.
+	ContextValues []*ContextValue
+
+	// ContextValue allows generating type-safe accessors for values that are
+	// stored in a context.Context. For example:
+	//
+	//	ContextValue{
+	//		Name:	"UserID",
+	//		Type:	"int",
+	//	}
+	//
+	// Generates the UserIDFromContext and the NewUserIDContext functions in the
+	// ent package. Users should use the entc.ContextValue option in order to
+	// build this annotation.
+	ContextValue struct {
+		// Name defines the name of the context value.
+		Name string
+		// Type defines the type identifier of the value. For example, `int`.
+		Type *field.TypeInfo
+	}
+)
+
+// Name describes the annotation name.
+func (ContextValues) Name() string {
+	return "ContextValues"
+}
+
+// Merge implements the schema.Merger interface.
+func (v ContextValues) Merge(other schema.Annotation) schema.Annotation {
+	if vs, ok := other.(ContextValues); ok {
+		return append(v, vs...)
+	}
+	return v
+}
+
+var _ interface {
+	schema.Annotation
+	schema.Merger
+} = (*ContextValues)(nil)
+
 func pkgf(s string) func(t *Type) string {
 	return func(t *Type) string { return fmt.Sprintf(s, t.PackageDir()) }
 }
@@ -20,8 +20,10 @@ import (
 	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/entc/load"
+	entschema "entgo.io/ent/schema"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
 )
 
 // The following types and their exported methods used by the codegen
@@ -32,6 +34,12 @@ type (
 	Type struct {
 		*Config
 		schema *load.Schema
+		// edgeContext indicates if the type is queried by edges
+		// that were defined with a context function (WithContext).
+		edgeContext bool
+		// defaultOrder indicates if the type is queried
+		// by edges that were defined with a default order.
+		defaultOrder bool
 		// Name holds the type/ent name.
 		Name string
 		// alias, or local package name of the generated package.
@@ -44,6 +52,10 @@ type (
 		fields map[string]*Field
 		// Edge holds all the edges of this type.
 		Edges []*Edge
+		// PolymorphicEdges holds the polymorphic edges of this type.
+		PolymorphicEdges []*PolymorphicEdge
+		// noder indicates if the type is a target of polymorphic edges.
+		noder bool
 		// Indexes are the configured indexes for this type.
 		Indexes []*Index
 		// ForeignKeys are the foreign-keys that resides in the type table.
@@ -62,8 +74,10 @@ type (
 
 	// Field holds the information of a type field used for the templates.
 	Field struct {
-		cfg *Config
-		def *load.Field
+		cfg   *Config
+		def   *load.Field
+		slug  *Field
+		audit *Field
 		// Name is the name of this field in the database schema.
 		Name string
 		// Type holds the type information of the field.
@@ -101,7 +115,8 @@ type (
 
 	// Edge of a graph between two types.
 	Edge struct {
-		def *load.Edge
+		def   *load.Edge
+		order *Field
 		// Name holds the name of the edge.
 		Name string
 		// Type holds a reference to the type this edge is directed to.
@@ -138,6 +153,22 @@ type (
 		Annotations Annotations
 	}
 
+	// PolymorphicEdge holds the information of an edge that was defined with
+	// schema.Polymorphic, and can point to entities of multiple types. It is
+	// stored in a pair of type and id fields of its owner type.
+	PolymorphicEdge struct {
+		def *load.Edge
+		// Name holds the name of the edge.
+		Name string
+		// Owner holds the type that holds the edge.
+		Owner *Type
+		// Types holds the types that the edge can point to.
+		Types []*Type
+		// TypeField and IDField hold the fields that store the
+		// type name and the id of the entity the edge points to.
+		TypeField, IDField *Field
+	}
+
 	// Relation holds the relational database information for edges.
 	Relation struct {
 		// Type holds the relation type of the edge.
@@ -163,11 +194,32 @@ type (
 		Unique bool
 		// Columns are the table columns.
 		Columns []string
+		// Expr holds the expression of function-based indexes.
+		Expr *IndexExpr
+		// Concurrent indicates if the index should be created
+		// concurrently, without locking the table against writes.
+		Concurrent bool
 		// Annotations that were defined for the index in the schema.
 		// The mapping is from the Annotation.Name() to a JSON decoded object.
 		Annotations Annotations
 	}
 
+	// IndexExpr holds the information of a function-based index. If the
+	// expression references exactly one field, it's used for generating
+	// predicates that apply the same expression on their argument.
+	IndexExpr struct {
+		// Expr is the SQL expression of the index.
+		Expr string
+		// Name of the generated predicates, without the operator
+		// suffix. For example, "CreatedAtMonth".
+		Name string
+		// Field that is referenced by the expression.
+		Field *Field
+		// Prefix and Suffix hold the parts of the
+		// expression before and after the field column.
+		Prefix, Suffix string
+	}
+
 	// ForeignKey holds the information for foreign-key columns of types.
 	// It's exported only because it's used by the codegen templates and
 	// should not be used beside that.
@@ -256,6 +308,77 @@ func NewType(c *Config, schema *load.Schema) (*Type, error) {
 			typ.fields[f.Name] = tf
 		}
 	}
+	for _, f := range typ.Fields {
+		if !f.def.Encrypted {
+			continue
+		}
+		switch {
+		case f.HasGoType():
+			return nil, fmt.Errorf("encrypted field %q cannot have a custom GoType", f.Name)
+		case f.Unique:
+			return nil, fmt.Errorf("encrypted field %q cannot be unique, as its values are encrypted with random nonces", f.Name)
+		}
+	}
+	for _, f := range typ.Fields {
+		if !f.def.Compressed {
+			continue
+		}
+		switch {
+		case f.HasGoType():
+			return nil, fmt.Errorf("compressed field %q cannot have a custom GoType", f.Name)
+		case f.Unique:
+			return nil, fmt.Errorf("compressed field %q cannot be unique", f.Name)
+		case f.def.Encrypted:
+			return nil, fmt.Errorf("compressed field %q cannot be encrypted", f.Name)
+		}
+	}
+	for _, f := range typ.Fields {
+		if f.def.AutoSlug == "" {
+			continue
+		}
+		if f.HasGoType() {
+			return nil, fmt.Errorf("slug field %q cannot have a custom GoType", f.Name)
+		}
+		src, ok := typ.fields[f.def.AutoSlug]
+		if !ok || src == f || src.Type.Type != field.TypeString {
+			return nil, fmt.Errorf("slug field %q: source field %q must be another string field", f.Name, f.def.AutoSlug)
+		}
+		f.slug = src
+	}
+	for _, f := range typ.Fields {
+		if f.def.AuditBy == "" {
+			continue
+		}
+		user, ok := typ.fields[f.def.AuditBy]
+		switch {
+		case !ok || user == f:
+			return nil, fmt.Errorf("audit field %q: user field %q must be another field of the type", f.Name, f.def.AuditBy)
+		case user.Immutable:
+			return nil, fmt.Errorf("audit field %q: user field %q cannot be immutable", f.Name, user.Name)
+		case user.IsJSON():
+			return nil, fmt.Errorf("audit field %q: user field %q cannot be a JSON field", f.Name, user.Name)
+		}
+		f.audit = user
+	}
+	for _, f := range typ.Fields {
+		if !f.IsCounter() {
+			continue
+		}
+		switch {
+		case !f.SupportsMutationAdd():
+			return nil, fmt.Errorf("counter field %q must be a numeric field that supports increments", f.Name)
+		case f.Immutable:
+			return nil, fmt.Errorf("counter field %q cannot be immutable", f.Name)
+		case f.UpdateDefault:
+			return nil, fmt.Errorf("counter field %q cannot have an update default", f.Name)
+		}
+	}
+	if ant := softDeleteAnnotate(typ.Annotations); ant != nil {
+		f, ok := typ.fields[ant.Field]
+		if !ok || f.Type.Type != field.TypeTime || !f.Optional || f.Immutable {
+			return nil, fmt.Errorf("soft delete field %q must be an optional and mutable time field of %s", ant.Field, typ.Name)
+		}
+	}
 	return typ, nil
 }
 
@@ -291,11 +414,38 @@ func (t Type) Table() string {
 	return snake(rules.Pluralize(t.Name))
 }
 
+// UnloggedTable returns the SQL table name of the unlogged copy
+// of the node/type, that is used by the sql/unlogged feature.
+func (t Type) UnloggedTable() string {
+	return t.Table() + "_unlogged"
+}
+
 // EntSQL returns the EntSQL annotation if exists.
 func (t Type) EntSQL() *entsql.Annotation {
 	return entsqlAnnotate(t.Annotations)
 }
 
+// SoftDeleteField returns the time field that marks the entities of the type as deleted,
+// if the type was defined with the SoftDelete mixin. Otherwise, it returns nil.
+func (t Type) SoftDeleteField() *Field {
+	if ant := softDeleteAnnotate(t.Annotations); ant != nil {
+		return t.fields[ant.Field]
+	}
+	return nil
+}
+
+// SoftDeleteCascadeEdges returns all edges of the type that propagate the soft-deletes
+// of its entities to the entities of the edge, using Cascade(edge.SoftDelete).
+func (t Type) SoftDeleteCascadeEdges() []*Edge {
+	var edges []*Edge
+	for _, e := range t.Edges {
+		if e.CascadeSoftDelete() {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
 // Package returns the package name of this node.
 func (t Type) Package() string {
 	if name := t.PackageAlias(); name != "" {
@@ -329,6 +479,12 @@ func (t Type) hasEdge(name string) bool {
 	return false
 }
 
+// IsNoder reports if the type is a target of polymorphic edges,
+// and implements the generated Noder interface.
+func (t Type) IsNoder() bool {
+	return t.noder
+}
+
 // HasAssoc returns true if this type has an assoc-edge (edge.To)
 // with the given name. faster than map access for most cases.
 func (t Type) HasAssoc(name string) (*Edge, bool) {
@@ -347,7 +503,7 @@ func (t Type) HasValidators() bool {
 		fields = append(fields, t.ID)
 	}
 	for _, f := range fields {
-		if f.Validators > 0 {
+		if f.Validators > 0 || f.ContextValidators() > 0 {
 			return true
 		}
 	}
@@ -401,7 +557,7 @@ func (t Type) HasNumeric() bool {
 // HasUpdateCheckers reports if this type has any checkers to run on update(one).
 func (t Type) HasUpdateCheckers() bool {
 	for _, f := range t.Fields {
-		if (f.Validators > 0 || f.IsEnum()) && !f.Immutable {
+		if (f.Validators > 0 || f.IsEnum() || f.IsCounter()) && !f.Immutable {
 			return true
 		}
 	}
@@ -448,7 +604,7 @@ func (t Type) MixedInFields() []int {
 		fields = append(fields, t.ID)
 	}
 	for _, f := range fields {
-		if f.Position != nil && f.Position.MixedIn && (f.Default || f.UpdateDefault || f.Validators > 0) {
+		if f.Position != nil && f.Position.MixedIn && (f.Default || f.UpdateDefault || f.Validators > 0 || f.ContextValidators() > 0 || f.Encrypted() || f.Compressed() || f.HasGoZeroValue()) {
 			idx[f.Position.MixinIndex] = struct{}{}
 		}
 	}
@@ -604,7 +760,10 @@ func (t Type) TagTypes() []string {
 // AddIndex adds a new index for the type.
 // It fails if the schema index is invalid.
 func (t *Type) AddIndex(idx *load.Index) error {
-	index := &Index{Name: idx.StorageKey, Unique: idx.Unique, Annotations: idx.Annotations}
+	if idx.Expr != "" {
+		return t.addExprIndex(idx)
+	}
+	index := &Index{Name: idx.StorageKey, Unique: idx.Unique, Concurrent: idx.Concurrent, Annotations: idx.Annotations}
 	if len(idx.Fields) == 0 && len(idx.Edges) == 0 {
 		return fmt.Errorf("missing fields or edges")
 	}
@@ -656,6 +815,108 @@ func (t *Type) AddIndex(idx *load.Index) error {
 	return nil
 }
 
+// addExprIndex adds a function-based index for the type.
+func (t *Type) addExprIndex(idx *load.Index) error {
+	switch {
+	case len(idx.Fields) > 0 || len(idx.Edges) > 0:
+		return fmt.Errorf("expression index %q cannot contain fields or edges", idx.Expr)
+	case idx.StorageKey == "":
+		return fmt.Errorf("missing name for expression index %q", idx.Expr)
+	}
+	expr := &IndexExpr{Expr: idx.Expr}
+	fields := t.Fields
+	if t.HasOneFieldID() {
+		fields = append([]*Field{t.ID}, fields...)
+	}
+	var (
+		refs     int
+		fn, text string
+	)
+	for _, tk := range exprTokens(idx.Expr) {
+		switch tk.kind {
+		case exprFunc:
+			if fn == "" {
+				fn = pascal(strings.ToLower(tk.value))
+			}
+		case exprString:
+			if name := pascal(strings.ToLower(tk.value)); text == "" && token.IsIdentifier(name) {
+				text = name
+			}
+		case exprIdent:
+			for _, f := range fields {
+				if f.StorageKey() == tk.value {
+					refs++
+					expr.Field, expr.Prefix, expr.Suffix = f, idx.Expr[:tk.pos], idx.Expr[tk.end:]
+				}
+			}
+		}
+	}
+	// String arguments (e.g. 'month') describe the expression better than its function name.
+	if expr.Name = text; expr.Name == "" {
+		expr.Name = fn
+	}
+	// Predicates are generated only for expressions on a single column.
+	if refs != 1 || expr.Name == "" {
+		expr.Field = nil
+	} else {
+		expr.Name = expr.Field.StructField() + expr.Name
+	}
+	t.Indexes = append(t.Indexes, &Index{Name: idx.StorageKey, Unique: idx.Unique, Expr: expr, Concurrent: idx.Concurrent, Annotations: idx.Annotations})
+	return nil
+}
+
+// Token kinds of index expressions.
+const (
+	exprIdent = iota
+	exprFunc
+	exprString
+)
+
+// exprToken represents a token in an index expression.
+type exprToken struct {
+	kind     int
+	value    string
+	pos, end int
+}
+
+// exprTokens returns the identifiers, the function names and the string literals of the given
+// SQL expression. Quoted identifiers (e.g. "created_at" or `created_at`) are returned unquoted.
+func exprTokens(expr string) []*exprToken {
+	var tokens []*exprToken
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == '\'':
+			j := strings.IndexByte(expr[i+1:], '\'')
+			if j == -1 {
+				return tokens
+			}
+			tokens = append(tokens, &exprToken{kind: exprString, value: expr[i+1 : i+1+j], pos: i, end: i + j + 2})
+			i += j + 2
+		case c == '"' || c == '`':
+			j := strings.IndexByte(expr[i+1:], c)
+			if j == -1 {
+				return tokens
+			}
+			tokens = append(tokens, &exprToken{kind: exprIdent, value: expr[i+1 : i+1+j], pos: i, end: i + j + 2})
+			i += j + 2
+		case c == '_' || unicode.IsLetter(rune(c)):
+			j := i + 1
+			for j < len(expr) && (expr[j] == '_' || unicode.IsLetter(rune(expr[j])) || unicode.IsDigit(rune(expr[j]))) {
+				j++
+			}
+			tk := &exprToken{kind: exprIdent, value: expr[i:j], pos: i, end: j}
+			if strings.HasPrefix(strings.TrimLeft(expr[j:], " "), "(") {
+				tk.kind = exprFunc
+			}
+			tokens = append(tokens, tk)
+			i = j
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
 // setupFKs makes sure all edge-fks are created for the edges.
 func (t *Type) setupFKs() error {
 	for _, e := range t.Edges {
@@ -830,6 +1091,198 @@ func (t Type) HookPositions() []*load.Position {
 	return nil
 }
 
+// BackfillEdges returns all edges of the type that were declared with a backfill function.
+func (t Type) BackfillEdges() []*Edge {
+	var edges []*Edge
+	for _, e := range t.Edges {
+		if e.BackfillPosition() != nil {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// SlugFields returns all fields of the type that are generated from other fields using AutoSlug.
+func (t Type) SlugFields() []*Field {
+	var fields []*Field
+	for _, f := range t.Fields {
+		if f.slug != nil {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// AuditFields returns all fields of the type that record the user that set them using AuditBy.
+func (t Type) AuditFields() []*Field {
+	var fields []*Field
+	for _, f := range t.Fields {
+		if f.audit != nil {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// PhoneFields returns all phone number fields of the type.
+func (t Type) PhoneFields() []*Field {
+	var fields []*Field
+	for _, f := range t.Fields {
+		if f.IsPhone() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// HumanReadableFields returns all human-readable duration fields of the type.
+func (t Type) HumanReadableFields() []*Field {
+	var fields []*Field
+	for _, f := range t.Fields {
+		if f.IsHumanReadable() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// LocalizedFields returns all fields of the type that store their translations per locale.
+func (t Type) LocalizedFields() []*Field {
+	var fields []*Field
+	for _, f := range t.Fields {
+		if f.IsLocalized() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// ContextValidatorFields returns all fields of the type that have context validators.
+func (t Type) ContextValidatorFields() []*Field {
+	var fields []*Field
+	for _, f := range t.Fields {
+		if f.ContextValidators() > 0 {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// URLFields returns all url fields of the type.
+func (t Type) URLFields() []*Field {
+	var fields []*Field
+	for _, f := range t.Fields {
+		if f.IsURL() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// ArrayFields returns all Postgres array fields of the type.
+func (t Type) ArrayFields() []*Field {
+	var fields []*Field
+	for _, f := range t.Fields {
+		if f.IsArray() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// InlineEdges returns all edges of the type that are preloaded as JSON columns.
+func (t Type) InlineEdges() []*Edge {
+	var edges []*Edge
+	for _, e := range t.Edges {
+		if e.InlineJSON() {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// MappedEdges returns all edges that expose their foreign-key value using a getter.
+func (t Type) MappedEdges() (edges []*Edge) {
+	for _, e := range t.Edges {
+		if e.MappedField() != "" {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// HasAppendFields reports if any of the type's fields supports the "Append(T)" method.
+func (t Type) HasAppendFields() bool {
+	for _, f := range t.Fields {
+		if f.SupportsMutationAppend() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasGoZeroValues reports if any of the type's fields has a custom Go zero value.
+func (t Type) HasGoZeroValues() bool {
+	for _, f := range t.Fields {
+		if f.HasGoZeroValue() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasEncryptedFields reports if any of the type's fields is encrypted.
+func (t Type) HasEncryptedFields() bool {
+	for _, f := range t.Fields {
+		if f.Encrypted() {
+			return true
+		}
+	}
+	return false
+}
+
+// CompressedFields returns all fields of the type that are compressed.
+func (t Type) CompressedFields() []*Field {
+	var fields []*Field
+	for _, f := range t.Fields {
+		if f.Compressed() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// HasDefaultOrder reports if the type is queried by edges that were defined with a default
+// order. In this case, its query builder tracks if the order was set by the edge definition.
+func (t Type) HasDefaultOrder() bool { return t.defaultOrder }
+
+// HasEdgeContext reports if the type is queried by edges that were defined with a
+// context function. In this case, its query builder holds the function and applies
+// it on the context the query is executed with.
+func (t Type) HasEdgeContext() bool { return t.edgeContext }
+
+// ContextEdges returns all edges of the type that were declared with a context function.
+func (t Type) ContextEdges() []*Edge {
+	var edges []*Edge
+	for _, e := range t.Edges {
+		if e.ContextPosition() != nil {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// JoinFilterEdges returns all edges of the type that were declared with a join filter.
+func (t Type) JoinFilterEdges() []*Edge {
+	var edges []*Edge
+	for _, e := range t.Edges {
+		if e.JoinFilterPosition() != nil {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
 // NumPolicy returns the number of privacy-policy declared in the type schema.
 func (t Type) NumPolicy() int {
 	if t.schema != nil {
@@ -952,6 +1405,105 @@ func (f Field) Constant() string {
 // DefaultName returns the variable name of the default value of this field.
 func (f Field) DefaultName() string { return "Default" + pascal(f.Name) }
 
+// DisplayName returns the human-readable name of the field, if it was set in the schema.
+func (f Field) DisplayName() string {
+	if f.def != nil {
+		return f.def.DisplayName
+	}
+	return ""
+}
+
+// DisplayNameConstant returns the constant name of the field display name.
+func (f Field) DisplayNameConstant() string { return pascal(f.Name) + "DisplayName" }
+
+// Widget returns the Widget annotation of the field, if exists.
+func (f Field) Widget() *entschema.Widget {
+	w := &entschema.Widget{}
+	if f.Annotations == nil || f.Annotations[w.Name()] == nil {
+		return nil
+	}
+	if buf, err := json.Marshal(f.Annotations[w.Name()]); err == nil {
+		_ = json.Unmarshal(buf, w)
+	}
+	return w
+}
+
+// WidgetConstant returns the constant name of the field widget type.
+func (f Field) WidgetConstant() string { return pascal(f.Name) + "Widget" }
+
+// MaxWords returns the maximum number of words allowed in
+// the field, or 0 if the field has no word count limit.
+func (f Field) MaxWords() int {
+	if f.def != nil {
+		return f.def.MaxWords
+	}
+	return 0
+}
+
+// SlugSource returns the source field of a field that was defined with
+// AutoSlug, or nil if the field value is not generated from another field.
+func (f Field) SlugSource() *Field { return f.slug }
+
+// AuditBy returns the user field of a field that was defined with AuditBy,
+// or nil if the field does not record the user that set it.
+func (f Field) AuditBy() *Field { return f.audit }
+
+// IsPhone reports if the field is a phone number field that was defined with field.Phone.
+func (f Field) IsPhone() bool { return f.def != nil && f.def.Phone }
+
+// IsURL reports if the field is a url field that was defined with field.URL.
+func (f Field) IsURL() bool { return f.def != nil && f.def.URL }
+
+// IsArray reports if the field is a Postgres array field that was defined with field.StringArray.
+func (f Field) IsArray() bool { return f.def != nil && f.def.Array }
+
+// IsCounter reports if the field is a counter that can only be incremented on update.
+func (f Field) IsCounter() bool { return f.def != nil && f.def.Counter }
+
+// IsHumanReadable reports if the field is a duration field that is displayed in its human-readable form.
+func (f Field) IsHumanReadable() bool { return f.def != nil && f.def.HumanReadable }
+
+// IsLocalized reports if the field stores its translations as a JSON map from their locale to
+// their value, and supports the methods for getting, setting and filtering a specific locale.
+func (f Field) IsLocalized() bool {
+	return f.def != nil && f.def.Locale != "" && f.IsJSON() &&
+		f.cfg != nil && f.cfg.Storage != nil && f.cfg.Storage.Name == "sql"
+}
+
+// DefaultLocale returns the default locale of a localized field.
+func (f Field) DefaultLocale() string {
+	if f.def == nil {
+		return ""
+	}
+	return f.def.Locale
+}
+
+// Encrypted reports if the field values are encrypted using EncryptVersioned.
+func (f Field) Encrypted() bool { return f.def != nil && f.def.Encrypted }
+
+// KeysName returns the variable name of the encryption keys of this field.
+func (f Field) KeysName() string { return pascal(f.Name) + "Keys" }
+
+// Compressed reports if the field values are compressed using Compress.
+func (f Field) Compressed() bool { return f.def != nil && f.def.Compressed }
+
+// CompressionName returns the variable name of the compression codec of this field.
+func (f Field) CompressionName() string { return pascal(f.Name) + "Compression" }
+
+// CompressedConstant returns the constant name of the column that
+// reports if the value of this field is stored compressed.
+func (f Field) CompressedConstant() string { return f.Constant() + "Compressed" }
+
+// CompressedStorageKey returns the name of the column that
+// reports if the value of this field is stored compressed.
+func (f Field) CompressedStorageKey() string { return f.StorageKey() + "_compressed" }
+
+// HasGoZeroValue reports if the field has a custom Go value that represents a NULL column.
+func (f Field) HasGoZeroValue() bool { return f.def != nil && f.def.GoZeroValue }
+
+// ZeroValueName returns the variable name of the Go zero value of this field.
+func (f Field) ZeroValueName() string { return "Zero" + pascal(f.Name) }
+
 // UpdateDefaultName returns the variable name of the update default value of this field.
 func (f Field) UpdateDefaultName() string { return "Update" + f.DefaultName() }
 
@@ -1009,6 +1561,19 @@ func (f Field) Validator() string {
 	return pascal(f.Name) + "Validator"
 }
 
+// ContextValidators returns the number of context validators the field has.
+func (f Field) ContextValidators() int {
+	if f.def == nil {
+		return 0
+	}
+	return f.def.ContextValidators
+}
+
+// ContextValidator returns the context validator name.
+func (f Field) ContextValidator() string {
+	return pascal(f.Name) + "ContextValidator"
+}
+
 // EntSQL returns the EntSQL annotation if exists.
 func (f Field) EntSQL() *entsql.Annotation {
 	return entsqlAnnotate(f.Annotations)
@@ -1125,6 +1690,15 @@ func (f Field) Edge() (*Edge, error) {
 // Sensitive returns true if the field is a sensitive field.
 func (f Field) Sensitive() bool { return f.def != nil && f.def.Sensitive }
 
+// SensitiveMask returns the number of visible characters of a masked
+// sensitive field, or 0 if the field is not masked.
+func (f Field) SensitiveMask() int {
+	if f.def != nil {
+		return f.def.SensitiveMask
+	}
+	return 0
+}
+
 // Comment returns the comment of the field,
 func (f Field) Comment() string {
 	if f.def != nil {
@@ -1276,8 +1850,16 @@ func (f Field) Column() *schema.Column {
 	if ant := f.EntSQL(); ant != nil && ant.Collation != "" {
 		c.Collation = ant.Collation
 	}
+	if ant := f.EntSQL(); ant != nil && ant.Storage != "" {
+		c.Storage = ant.Storage
+	}
 	if f.def != nil {
 		c.SchemaType = f.def.SchemaType
+		c.MaxWords = f.def.MaxWords
+	}
+	// Compressed values are stored as raw bytes.
+	if f.Compressed() {
+		c.Type = field.TypeBytes
 	}
 	return c
 }
@@ -1296,6 +1878,15 @@ func (f Field) size() int64 {
 	if ant := f.EntSQL(); ant != nil && ant.Size != 0 {
 		return ant.Size
 	}
+	// The column of encrypted fields holds the ciphertext, and therefore,
+	// it should be big enough for the encryption of the largest value.
+	if f.Encrypted() {
+		n := int64(schema.DefaultStringLen)
+		if f.def.Size != nil {
+			n = *f.def.Size
+		}
+		return field.EncryptedSize(n)
+	}
 	if f.def != nil && f.def.Size != nil {
 		return *f.def.Size
 	}
@@ -1382,6 +1973,21 @@ func (f Field) SupportsMutationAdd() bool {
 	return f.ConvertedToBasic() || f.implementsAdder()
 }
 
+// SupportsMutationAppend reports if the field supports the mutation "Append(T)" method,
+// that appends elements to a JSON array in the database without reading it first.
+func (f Field) SupportsMutationAppend() bool {
+	return f.IsJSON() && f.Type.RType != nil && f.Type.RType.Kind == reflect.Slice &&
+		f.cfg != nil && f.cfg.Storage != nil && f.cfg.Storage.Name == "sql"
+}
+
+// AppendType returns the element type of a field that supports the "Append(T)" method.
+func (f Field) AppendType() string {
+	if e := f.Type.RType.Elem; e != nil {
+		return e.String()
+	}
+	return strings.TrimPrefix(f.Type.String(), "[]")
+}
+
 // MutationAddAssignExpr returns the expression for summing to identifiers and assigning to the mutation field.
 //
 //	MutationAddAssignExpr(a, b) => *m.a += b		// Basic Go type.
@@ -1520,7 +2126,7 @@ func (f Field) enums(lf *load.Field) ([]Enum, error) {
 // Ops returns all predicate operations of the field.
 func (f *Field) Ops() []Op {
 	ops := fieldOps(f)
-	if f.Name != "id" && f.cfg != nil && f.cfg.Storage.Ops != nil {
+	if f.Name != "id" && !f.Encrypted() && f.cfg != nil && f.cfg.Storage.Ops != nil {
 		ops = append(ops, f.cfg.Storage.Ops(f)...)
 	}
 	return ops
@@ -1602,6 +2208,16 @@ func (e Edge) StructField() string {
 	return pascal(e.Name)
 }
 
+// StructField returns the struct member of the edge in the model.
+func (e PolymorphicEdge) StructField() string {
+	return pascal(e.Name)
+}
+
+// Comment returns the comment of the edge.
+func (e PolymorphicEdge) Comment() string {
+	return e.def.Comment
+}
+
 // OwnFK indicates if the foreign-key of this edge is owned by the edge
 // column (reside in the type's table). Used by the SQL storage-driver.
 func (e Edge) OwnFK() bool {
@@ -1647,6 +2263,80 @@ func (e Edge) Comment() string {
 	return ""
 }
 
+// JoinFilterPosition returns the position of the edge in the type schema,
+// or nil if the edge was not declared with a join filter.
+func (e Edge) JoinFilterPosition() *load.Position {
+	if e.def == nil {
+		return nil
+	}
+	return e.def.JoinFilter
+}
+
+// ContextPosition returns the position of the edge in the type schema,
+// or nil if the edge was not declared with a context function.
+func (e Edge) ContextPosition() *load.Position {
+	if e.def == nil {
+		return nil
+	}
+	return e.def.Context
+}
+
+// ContextName returns the name of the variable that holds the context function of the edge.
+func (e Edge) ContextName() string {
+	return e.StructField() + "Context"
+}
+
+// JoinFilterName returns the name of the variable that holds the join filter of the edge.
+func (e Edge) JoinFilterName() string {
+	return e.StructField() + "JoinFilter"
+}
+
+// BackfillPosition returns the position of the edge in the type schema,
+// or nil if the edge was not declared with a backfill function.
+func (e Edge) BackfillPosition() *load.Position {
+	if e.def == nil {
+		return nil
+	}
+	return e.def.Backfill
+}
+
+// OrderField returns the field that is used for ordering the edge queries by default,
+// or nil if the edge was not defined with a default order.
+func (e Edge) OrderField() *Field { return e.order }
+
+// InlineJSON reports if the edge is preloaded with its parent entities
+// as a JSON column, using Preload(edge.InlineJSON).
+func (e Edge) InlineJSON() bool { return e.def != nil && e.def.Preload == edge.InlineJSON }
+
+// CascadeSoftDelete reports if the soft-deletes of the edge owner are
+// propagated to the entities of the edge, using Cascade(edge.SoftDelete).
+func (e Edge) CascadeSoftDelete() bool { return e.def != nil && e.def.Cascade == edge.SoftDelete }
+
+// MappedField returns the name of the getter method that exposes the foreign-key
+// value of the edge, or an empty string if the edge was not defined with MapToField.
+func (e Edge) MappedField() string {
+	if e.def == nil || e.def.MapToField == "" {
+		return ""
+	}
+	return pascal(e.def.MapToField)
+}
+
+// InlineConstant returns the constant name of the column alias that holds
+// the edge preloaded as JSON.
+func (e Edge) InlineConstant() string { return pascal(e.Name) + "InlineColumn" }
+
+// InlineColumn returns the column alias that holds the edge preloaded as JSON.
+func (e Edge) InlineColumn() string { return e.Name + "_json" }
+
+// OrderFunc returns the name of the order function (Asc or Desc) that is
+// used for ordering the edge queries by default.
+func (e Edge) OrderFunc() string {
+	if e.def != nil && e.def.OrderBy != nil && strings.EqualFold(e.def.OrderBy.Direction, "DESC") {
+		return "Desc"
+	}
+	return "Asc"
+}
+
 // HasFieldSetter reports if this edge already has a field-edge setters for its mutation API.
 // It's used by the codegen templates to avoid generating duplicate setters for id APIs (e.g. SetOwnerID).
 func (e Edge) HasFieldSetter() bool {
@@ -1737,19 +2427,39 @@ func (e *Edge) setStorageKey() error {
 
 // StorageKey returns the storage-key defined on the schema if exists.
 func (e Edge) StorageKey() (*edge.StorageKey, error) {
-	key := e.def.StorageKey
-	if !e.IsInverse() {
-		return key, nil
+	key, err := e.storageKey()
+	if err != nil || !e.IsInverse() {
+		return key, err
 	}
 	assoc, ok := e.Owner.HasAssoc(e.Inverse)
-	if !ok || assoc.def.StorageKey == nil {
+	if !ok {
 		return key, nil
 	}
+	akey, err := assoc.storageKey()
+	if err != nil || akey == nil {
+		return key, err
+	}
 	// Assoc/To edge found with storage-key configured.
 	if key != nil {
 		return nil, fmt.Errorf("multiple storage-keys defined for edge %q<->%q", e.Name, assoc.Name)
 	}
-	return assoc.def.StorageKey, nil
+	return akey, nil
+}
+
+// storageKey returns the storage-key defined on the edge, using either
+// the edge.StorageKey option or the entsql.JoinTable annotation.
+func (e Edge) storageKey() (*edge.StorageKey, error) {
+	ant := e.EntSQL()
+	if ant == nil || ant.JoinTable == "" && len(ant.JoinColumns) == 0 {
+		return e.def.StorageKey, nil
+	}
+	if e.def.StorageKey != nil {
+		return nil, fmt.Errorf("edge %q: entsql.JoinTable cannot be used together with edge.StorageKey", e.Name)
+	}
+	if len(ant.JoinColumns) != 0 && len(ant.JoinColumns) != 2 {
+		return nil, fmt.Errorf("edge %q: entsql.JoinTable expects 2 join columns, got %d", e.Name, len(ant.JoinColumns))
+	}
+	return &edge.StorageKey{Table: ant.JoinTable, Columns: ant.JoinColumns}, nil
 }
 
 // EntSQL returns the EntSQL annotation if exists.
@@ -1847,6 +2557,18 @@ func entsqlAnnotate(annotation map[string]interface{}) *entsql.Annotation {
 	return annotate
 }
 
+// softDeleteAnnotate extracts the soft delete annotation from a loaded annotation format.
+func softDeleteAnnotate(annotation map[string]interface{}) *mixin.SoftDeleteAnnotation {
+	annotate := &mixin.SoftDeleteAnnotation{}
+	if annotation == nil || annotation[annotate.Name()] == nil {
+		return nil
+	}
+	if buf, err := json.Marshal(annotation[annotate.Name()]); err == nil {
+		_ = json.Unmarshal(buf, &annotate)
+	}
+	return annotate
+}
+
 // entsqlIndexAnnotate extracts the entsql annotation from a loaded annotation format.
 func entsqlIndexAnnotate(annotation map[string]interface{}) *entsql.IndexAnnotation {
 	annotate := &entsql.IndexAnnotation{}
@@ -5,8 +5,10 @@
 package gen
 
 import (
+	"reflect"
 	"testing"
 
+	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/entc/load"
 	"entgo.io/ent/schema/field"
 
@@ -78,6 +80,148 @@ func TestType(t *testing.T) {
 	})
 	require.EqualError(err, "id field cannot be optional", "id field cannot be optional")
 
+	typ, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "title", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "slug", Info: &field.TypeInfo{Type: field.TypeString}, AutoSlug: "title"},
+		},
+	})
+	require.NoError(err)
+	require.Equal([]*Field{typ.Fields[1]}, typ.SlugFields())
+	require.Equal(typ.Fields[0], typ.Fields[1].SlugSource())
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "title", Info: &field.TypeInfo{Type: field.TypeInt}},
+			{Name: "slug", Info: &field.TypeInfo{Type: field.TypeString}, AutoSlug: "title"},
+		},
+	})
+	require.EqualError(err, "slug field \"slug\": source field \"title\" must be another string field")
+	typ, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "status", Info: &field.TypeInfo{Type: field.TypeString}, AuditBy: "updated_by"},
+			{Name: "updated_by", Info: &field.TypeInfo{Type: field.TypeInt}, Optional: true},
+		},
+	})
+	require.NoError(err)
+	require.Equal([]*Field{typ.Fields[0]}, typ.AuditFields())
+	require.Equal(typ.Fields[1], typ.Fields[0].AuditBy())
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "status", Info: &field.TypeInfo{Type: field.TypeString}, AuditBy: "status"},
+		},
+	})
+	require.EqualError(err, "audit field \"status\": user field \"status\" must be another field of the type")
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "status", Info: &field.TypeInfo{Type: field.TypeString}, AuditBy: "updated_by"},
+			{Name: "updated_by", Info: &field.TypeInfo{Type: field.TypeInt}, Immutable: true},
+		},
+	})
+	require.EqualError(err, "audit field \"status\": user field \"updated_by\" cannot be immutable")
+	typ, err = NewType(&Config{Package: "entc/gen", Storage: drivers[0]}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "mobile", Info: &field.TypeInfo{Type: field.TypeString}, Phone: true},
+			{Name: "website", Info: &field.TypeInfo{Type: field.TypeString}, URL: true},
+			{Name: "tags", Info: field.StringArray("tags").Descriptor().Info, SchemaType: field.StringArray("tags").Descriptor().SchemaType, Array: true},
+			{Name: "timeout", Info: &field.TypeInfo{Type: field.TypeInt64, Ident: "time.Duration", PkgPath: "time"}, HumanReadable: true},
+		},
+	})
+	require.NoError(err)
+	require.False(typ.Fields[0].IsPhone())
+	require.Equal([]*Field{typ.Fields[1]}, typ.PhoneFields())
+	require.False(typ.Fields[1].IsURL())
+	require.Equal([]*Field{typ.Fields[2]}, typ.URLFields())
+	require.False(typ.Fields[2].IsArray())
+	require.Equal([]*Field{typ.Fields[3]}, typ.ArrayFields())
+	require.Empty(typ.Fields[3].Ops())
+	require.False(typ.Fields[3].IsHumanReadable())
+	require.Equal([]*Field{typ.Fields[4]}, typ.HumanReadableFields())
+	typ, err = NewType(&Config{Package: "entc/gen", Storage: drivers[0]}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "title", Info: &field.TypeInfo{Type: field.TypeJSON, Ident: "map[string]string", RType: &field.RType{Kind: reflect.Map}}, Locale: "en"},
+		},
+	})
+	require.NoError(err)
+	require.False(typ.Fields[0].IsLocalized())
+	require.True(typ.Fields[1].IsLocalized())
+	require.Equal("en", typ.Fields[1].DefaultLocale())
+	require.Equal([]*Field{typ.Fields[1]}, typ.LocalizedFields())
+	typ, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}, Validators: 1},
+			{Name: "username", Info: &field.TypeInfo{Type: field.TypeString}, ContextValidators: 2},
+		},
+	})
+	require.NoError(err)
+	require.Zero(typ.Fields[0].ContextValidators())
+	require.Equal(2, typ.Fields[1].ContextValidators())
+	require.Equal("UsernameContextValidator", typ.Fields[1].ContextValidator())
+	require.Equal([]*Field{typ.Fields[1]}, typ.ContextValidatorFields())
+	require.True(typ.HasValidators())
+	require.Nil(typ.SoftDeleteField())
+
+	typ, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "delete_time", Info: &field.TypeInfo{Type: field.TypeTime}, Optional: true, Nillable: true},
+		},
+		Annotations: map[string]interface{}{"SoftDelete": map[string]interface{}{"Field": "delete_time"}},
+	})
+	require.NoError(err)
+	require.Equal(typ.Fields[1], typ.SoftDeleteField())
+
+	typ, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "age", Info: &field.TypeInfo{Type: field.TypeInt}},
+			{Name: "views", Info: &field.TypeInfo{Type: field.TypeInt}, Counter: true},
+		},
+	})
+	require.NoError(err)
+	require.False(typ.Fields[0].IsCounter())
+	require.True(typ.Fields[1].IsCounter())
+	require.True(typ.HasUpdateCheckers())
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "views", Info: &field.TypeInfo{Type: field.TypeInt}, Counter: true, Immutable: true},
+		},
+	})
+	require.EqualError(err, `counter field "views" cannot be immutable`)
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "delete_time", Info: &field.TypeInfo{Type: field.TypeTime}},
+		},
+		Annotations: map[string]interface{}{"SoftDelete": map[string]interface{}{"Field": "delete_time"}},
+	})
+	require.EqualError(err, `soft delete field "delete_time" must be an optional and mutable time field of T`)
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "ssn", Info: &field.TypeInfo{Type: field.TypeString}, Unique: true, Encrypted: true},
+		},
+	})
+	require.EqualError(err, "encrypted field \"ssn\" cannot be unique, as its values are encrypted with random nonces")
+	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{
+		Name: "T",
+		Fields: []*load.Field{
+			{Name: "body", Info: &field.TypeInfo{Type: field.TypeString}, Unique: true, Compressed: true},
+		},
+	})
+	require.EqualError(err, "compressed field \"body\" cannot be unique")
+
 	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{Name: "Type"})
 	require.EqualError(err, "schema lowercase name conflicts with Go keyword \"type\"")
 	_, err = NewType(&Config{Package: "entc/gen"}, &load.Schema{Name: "Int"})
@@ -249,6 +393,44 @@ func TestType_AddIndex(t *testing.T) {
 	require.NoError(t, err, "valid index on M2O relation and field")
 }
 
+func TestType_AddExprIndex(t *testing.T) {
+	typ, err := NewType(&Config{}, &load.Schema{
+		Name: "User",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "created_at", Info: &field.TypeInfo{Type: field.TypeTime}},
+		},
+	})
+	require.NoError(t, err)
+
+	err = typ.AddIndex(&load.Index{Expr: "LOWER(name)"})
+	require.EqualError(t, err, `missing name for expression index "LOWER(name)"`)
+	err = typ.AddIndex(&load.Index{Expr: "LOWER(name)", StorageKey: "lower_name", Fields: []string{"name"}})
+	require.EqualError(t, err, `expression index "LOWER(name)" cannot contain fields or edges`)
+
+	require.NoError(t, typ.AddIndex(&load.Index{Expr: "DATE_TRUNC('month', created_at)", StorageKey: "idx_users_month"}))
+	expr := typ.Indexes[0].Expr
+	require.Equal(t, "idx_users_month", typ.Indexes[0].Name)
+	require.Equal(t, "CreatedAtMonth", expr.Name)
+	require.Equal(t, "created_at", expr.Field.Name)
+	require.Equal(t, "DATE_TRUNC('month', ", expr.Prefix)
+	require.Equal(t, ")", expr.Suffix)
+
+	require.NoError(t, typ.AddIndex(&load.Index{Expr: "lower(\"name\")", StorageKey: "lower_name", Unique: true}))
+	expr = typ.Indexes[1].Expr
+	require.True(t, typ.Indexes[1].Unique)
+	require.Equal(t, "NameLower", expr.Name)
+	require.Equal(t, "lower(", expr.Prefix)
+	require.Equal(t, ")", expr.Suffix)
+
+	// Predicates are not generated for expressions on multiple columns.
+	require.NoError(t, typ.AddIndex(&load.Index{Expr: "CONCAT(name, created_at)", StorageKey: "name_created_at"}))
+	require.Nil(t, typ.Indexes[2].Expr.Field)
+
+	require.NoError(t, typ.AddIndex(&load.Index{Fields: []string{"name"}, Concurrent: true}))
+	require.True(t, typ.Indexes[3].Concurrent)
+}
+
 func TestField_Constant(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -296,6 +478,39 @@ func TestField_incremental(t *testing.T) {
 	}
 }
 
+func TestField_Encrypted(t *testing.T) {
+	size := int64(10)
+	f := &Field{Name: "ssn", Type: &field.TypeInfo{Type: field.TypeString}, Optional: true, def: &load.Field{Encrypted: true}, cfg: &Config{Storage: drivers[0]}}
+	require.Equal(t, []Op{IsNil, NotNil}, f.Ops())
+	require.Equal(t, field.EncryptedSize(schema.DefaultStringLen), f.Column().Size)
+	f.def.Size = &size
+	require.Equal(t, field.EncryptedSize(size), f.Column().Size)
+	f.Annotations = dict("EntSQL", dict("size", 1024))
+	require.Equal(t, int64(1024), f.Column().Size)
+}
+
+func TestField_SupportsMutationAppend(t *testing.T) {
+	info := &field.TypeInfo{Type: field.TypeJSON, Ident: "[]string", RType: &field.RType{Kind: reflect.Slice}}
+	f := &Field{Name: "tags", Type: info, cfg: &Config{Storage: drivers[0]}}
+	require.True(t, f.SupportsMutationAppend())
+	require.Equal(t, "string", f.AppendType())
+	f.Type = &field.TypeInfo{Type: field.TypeJSON, Ident: "schema.Aliases", RType: &field.RType{Kind: reflect.Slice, Elem: &field.RType{Ident: "string", Kind: reflect.String}}}
+	require.Equal(t, "string", f.AppendType())
+	f.cfg.Storage = drivers[1]
+	require.False(t, f.SupportsMutationAppend())
+	f = &Field{Name: "dict", Type: &field.TypeInfo{Type: field.TypeJSON, RType: &field.RType{Kind: reflect.Map}}, cfg: &Config{Storage: drivers[0]}}
+	require.False(t, f.SupportsMutationAppend())
+}
+
+func TestField_Widget(t *testing.T) {
+	f := &Field{Name: "color"}
+	require.Nil(t, f.Widget())
+	f.Annotations = dict("Widget", dict("type", "color-picker", "options", dict("swatches", true)))
+	require.Equal(t, "color-picker", f.Widget().Type)
+	require.Equal(t, map[string]interface{}{"swatches": true}, f.Widget().Options)
+	require.Equal(t, "ColorWidget", f.WidgetConstant())
+}
+
 func TestBuilderField(t *testing.T) {
 	tests := []struct {
 		name  string
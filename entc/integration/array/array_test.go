@@ -0,0 +1,66 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package array
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/array/ent/enttest"
+	"entgo.io/ent/entc/integration/array/ent/user"
+	"entgo.io/ent/schema/field"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringArraySQLite(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	u := client.User.Create().SetName("a8m").SetTags(field.TextArray{"a", `b "c"`, "d,e"}).SaveX(ctx)
+	require.Equal(t, field.TextArray{"a", `b "c"`, "d,e"}, client.User.GetX(ctx, u.ID).Tags)
+	u = client.User.Create().SetName("nati").SaveX(ctx)
+	require.Nil(t, client.User.GetX(ctx, u.ID).Tags)
+
+	_, err := client.User.Query().Where(user.TagsContains("a")).All(ctx)
+	require.EqualError(t, err, "user.TagsContains: array predicates are supported only by PostgreSQL")
+	_, err = client.User.Query().Where(user.TagsLength(1)).Count(ctx)
+	require.EqualError(t, err, "user.TagsLength: array predicates are supported only by PostgreSQL")
+}
+
+func TestStringArrayPredicates(t *testing.T) {
+	for _, tt := range []struct {
+		p     func(*sql.Selector)
+		query string
+		args  []interface{}
+	}{
+		{
+			p:     user.TagsContains("a"),
+			query: `SELECT * FROM "users" WHERE $1 = ANY("users"."tags")`,
+			args:  []interface{}{"a"},
+		},
+		{
+			p:     user.TagsLength(2),
+			query: `SELECT * FROM "users" WHERE COALESCE(array_length("users"."tags", 1), 0) = $1`,
+			args:  []interface{}{2},
+		},
+		{
+			p:     user.TagsOverlaps([]string{"a", "b"}),
+			query: `SELECT * FROM "users" WHERE "users"."tags" && $1::text[]`,
+			args:  []interface{}{field.TextArray{"a", "b"}},
+		},
+	} {
+		s := sql.Dialect(dialect.Postgres).Select("*").From(sql.Table(user.Table))
+		tt.p(s)
+		query, args := s.Query()
+		require.NoError(t, s.Err())
+		require.Equal(t, tt.query, query)
+		require.Equal(t, tt.args, args)
+	}
+}
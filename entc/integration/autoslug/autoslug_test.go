@@ -0,0 +1,80 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package autoslug
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/entc/integration/autoslug/ent"
+	"entgo.io/ent/entc/integration/autoslug/ent/enttest"
+	"entgo.io/ent/entc/integration/autoslug/ent/hook"
+	"entgo.io/ent/entc/integration/autoslug/ent/post"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoSlug(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	p := client.Post.Create().SetTitle("Crème Brûlée!").SaveX(ctx)
+	require.Equal(t, "creme-brulee", p.Slug)
+	p = client.Post.Create().SetTitle("Crème brûlée").SaveX(ctx)
+	require.Equal(t, "creme-brulee-2", p.Slug)
+	p = client.Post.Create().SetTitle("Crème brûlée").SaveX(ctx)
+	require.Equal(t, "creme-brulee-3", p.Slug)
+
+	// Slugs that only share the prefix are not counted as collisions.
+	client.Post.Create().SetTitle("Hello World 2024").SaveX(ctx)
+	p = client.Post.Create().SetTitle("Hello World").SaveX(ctx)
+	require.Equal(t, "hello-world", p.Slug)
+	p = client.Post.Create().SetTitle("Hello World").SaveX(ctx)
+	require.Equal(t, "hello-world-2", p.Slug)
+
+	// Explicit slugs are kept as-is.
+	p = client.Post.Create().SetTitle("Hello World").SetSlug("custom").SaveX(ctx)
+	require.Equal(t, "custom", p.Slug)
+	_, err := client.Post.Create().SetTitle("Hello World").SetSlug("custom").Save(ctx)
+	require.True(t, ent.IsConstraintError(err), "slug fields are unique")
+
+	// Slugs are filled in the gaps of deleted posts.
+	client.Post.Delete().Where(post.Slug("creme-brulee-2")).ExecX(ctx)
+	p = client.Post.Create().SetTitle("Crème brûlée").SaveX(ctx)
+	require.Equal(t, "creme-brulee-2", p.Slug)
+}
+
+func TestAutoSlugRetry(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	// Simulate a concurrent creation that takes the generated slug
+	// after it was queried, but before the post is created.
+	var calls int
+	client.Post.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.PostFunc(func(ctx context.Context, m *ent.PostMutation) (ent.Value, error) {
+			if calls++; calls == 1 {
+				s, _ := m.Slug()
+				m.Client().Post.Create().SetTitle("Concurrent").SetSlug(s).SaveX(ctx)
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+	p := client.Post.Create().SetTitle("Hello World").SaveX(ctx)
+	require.Equal(t, "hello-world-2", p.Slug)
+	require.Equal(t, 3, calls, "concurrent creation and a retry")
+
+	// Creations are not retried in transactions.
+	calls = 0
+	tx, err := client.Tx(ctx)
+	require.NoError(t, err)
+	_, err = tx.Post.Create().SetTitle("Go").Save(ctx)
+	require.True(t, ent.IsConstraintError(err))
+	require.Equal(t, 2, calls)
+	require.NoError(t, tx.Rollback())
+}
@@ -0,0 +1,54 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"entgo.io/ent/entc/integration/cache/ent/cache"
+	"entgo.io/ent/entc/integration/cache/ent/enttest"
+	"entgo.io/ent/entc/integration/cache/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	b := cache.NewMemoryBackend()
+	users := cache.NewUserCache(client.User, time.Minute, cache.WithBackend(b))
+	u := users.Create().SetName("a8m").SetPassword("secret").SetToken([]byte("token")).SaveX(ctx)
+
+	// Miss.
+	require.Equal(t, "secret", users.GetX(ctx, u.ID).Password)
+	// Hit. Change the database behind the cache to ensure it is not queried.
+	other := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer other.Close()
+	other.User.UpdateOneID(u.ID).SetName("changed").ExecX(ctx)
+	cached := users.GetX(ctx, u.ID)
+	require.Equal(t, "a8m", cached.Name)
+	require.Equal(t, "secret", cached.Password, "sensitive fields are kept on hit")
+	require.Equal(t, []byte("token"), cached.Token)
+	require.Equal(t, "changed", cached.Update().SaveX(ctx).Name, "cached entity is attached to the client")
+
+	nodes, err := users.QueryAll(ctx, "all", client.User.Query().Where(user.Password("secret")))
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	nodes, err = users.QueryAll(ctx, "all", client.User.Query())
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	require.Equal(t, "secret", nodes[0].Password)
+	require.Equal(t, []byte("token"), nodes[0].Token)
+
+	// Invalidation.
+	users.UpdateOneID(u.ID).SetPassword("changed").ExecX(ctx)
+	require.Equal(t, "changed", users.GetX(ctx, u.ID).Password)
+	require.Equal(t, "changed", users.GetX(ctx, u.ID).Password)
+}
@@ -0,0 +1,53 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package cacherepo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"entgo.io/ent/entc/integration/cacherepo/ent"
+	"entgo.io/ent/entc/integration/cacherepo/ent/cache"
+	"entgo.io/ent/entc/integration/cacherepo/ent/enttest"
+	"entgo.io/ent/entc/integration/cacherepo/ent/role"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedRepo(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:cacherepo?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	require.Equal(t, time.Minute, cache.DefaultRepoTTL)
+
+	repo := cache.NewCachedRoleRepo(client, 0)
+	r := client.Role.Create().SetName("admin").SetAdmin(true).SaveX(ctx)
+	require.Same(t, repo.GetX(ctx, r.ID), repo.GetX(ctx, r.ID), "entity should be loaded from the cache")
+	admins := repo.QueryX(ctx, "admins", client.Role.Query().Where(role.Admin(true)))
+	require.Len(t, admins, 1)
+
+	// Changes that are applied by other clients are visible only after the entries expire.
+	other := enttest.Open(t, "sqlite3", "file:cacherepo?mode=memory&cache=shared&_fk=1")
+	defer other.Close()
+	short := cache.NewCachedRoleRepo(client, 50*time.Millisecond)
+	require.Equal(t, "admin", short.GetX(ctx, r.ID).Name)
+	other.Role.UpdateOneID(r.ID).SetName("root").ExecX(ctx)
+	require.Equal(t, "admin", repo.GetX(ctx, r.ID).Name)
+	require.Equal(t, "admin", short.GetX(ctx, r.ID).Name)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, "root", short.GetX(ctx, r.ID).Name)
+
+	// Mutations invalidate the cached entities and queries.
+	client.Role.UpdateOne(r).SetName("superuser").ExecX(ctx)
+	require.Equal(t, "superuser", repo.GetX(ctx, r.ID).Name)
+	client.Role.Create().SetName("owner").SetAdmin(true).ExecX(ctx)
+	require.Len(t, repo.QueryX(ctx, "admins", client.Role.Query().Where(role.Admin(true))), 2)
+	client.Role.DeleteOne(r).ExecX(ctx)
+	_, err := repo.Get(ctx, r.ID)
+	require.True(t, ent.IsNotFound(err))
+	require.Len(t, repo.QueryX(ctx, "admins", client.Role.Query().Where(role.Admin(true))), 1)
+}
@@ -0,0 +1,80 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package changelog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/changelog/ent"
+	"entgo.io/ent/entc/integration/changelog/ent/enttest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangelog(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.Postgres, db)))
+	client.Use(ent.ChangelogHook())
+
+	// Updates outside of a transaction are rejected before they are executed.
+	err = client.User.UpdateOneID(1).SetName("a8m").Exec(ctx)
+	require.EqualError(t, err, "ent: changelog requires the mutation to be executed in a transaction")
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// The snapshot, the update and the changelog entries are executed in the same transaction.
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT jsonb_object_agg\(.*\) FROM "users"`).
+		WillReturnRows(sqlmock.NewRows([]string{"snapshot"}).AddRow([]byte(`{"1":{"id":1,"name":"a8m"}}`)))
+	mock.ExpectExec(`UPDATE "users" SET "name"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT .* FROM "users"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Ariel"))
+	mock.ExpectExec(`INSERT INTO "ent_changelog"`).
+		WithArgs("User", `{"1":{"id":1,"name":"a8m"}}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	tx, err := client.Tx(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Ariel", tx.User.UpdateOneID(1).SetName("Ariel").SaveX(ctx).Name)
+	require.NoError(t, tx.Commit())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// A failure in recording the changes fails the mutation, and the update is rolled back with the transaction.
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT "users"."id" FROM "users"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`SELECT jsonb_object_agg\(.*\) FROM "users"`).
+		WillReturnRows(sqlmock.NewRows([]string{"snapshot"}).AddRow([]byte(`{"1":{"id":1,"name":"Ariel"}}`)))
+	mock.ExpectExec(`UPDATE "users" SET "name"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO "ent_changelog"`).WillReturnError(errors.New("boom"))
+	mock.ExpectRollback()
+	tx, err = client.Tx(ctx)
+	require.NoError(t, err)
+	err = tx.User.Update().SetName("a8m").Exec(ctx)
+	require.EqualError(t, err, "ent: recording changelog: boom")
+	require.NoError(t, tx.Rollback())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChangelogDialect(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:changelog?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	client.Use(ent.ChangelogHook())
+
+	u := client.User.Create().SetName("a8m").SaveX(ctx)
+	tx, err := client.Tx(ctx)
+	require.NoError(t, err)
+	err = tx.User.UpdateOne(u).SetName("Ariel").Exec(ctx)
+	require.EqualError(t, err, "ent: changelog is not supported by the sqlite3 dialect")
+	require.NoError(t, tx.Rollback())
+	require.Equal(t, "a8m", client.User.GetX(ctx, u.ID).Name)
+}
@@ -0,0 +1,56 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package contextvalue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"entgo.io/ent/entc/integration/contextvalue/ent"
+	"entgo.io/ent/entc/integration/contextvalue/ent/enttest"
+	"entgo.io/ent/entc/integration/contextvalue/ent/hook"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextValue(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:contextvalue?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	_, ok := ent.UserIDFromContext(ctx)
+	require.False(t, ok)
+	uctx := ent.NewUserIDContext(ctx, 1)
+	id, ok := ent.UserIDFromContext(uctx)
+	require.True(t, ok)
+	require.Equal(t, 1, id)
+	// Values are stored under different keys.
+	_, ok = ent.DeadlineFromContext(uctx)
+	require.False(t, ok)
+	deadline := time.Now().Add(time.Minute)
+	dctx := ent.NewDeadlineContext(uctx, deadline)
+	d, ok := ent.DeadlineFromContext(dctx)
+	require.True(t, ok)
+	require.True(t, deadline.Equal(d))
+	id, ok = ent.UserIDFromContext(dctx)
+	require.True(t, ok)
+	require.Equal(t, 1, id)
+
+	// Values are accessible in hooks.
+	client.User.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.UserFunc(func(ctx context.Context, m *ent.UserMutation) (ent.Value, error) {
+			if _, ok := ent.UserIDFromContext(ctx); !ok {
+				return nil, errors.New("missing user in context")
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+	err := client.User.Create().SetName("a8m").Exec(ctx)
+	require.EqualError(t, err, "missing user in context")
+	client.User.Create().SetName("a8m").ExecX(uctx)
+}
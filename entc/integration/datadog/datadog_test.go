@@ -0,0 +1,69 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package datadog
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/entc/integration/datadog/ent"
+	"entgo.io/ent/entc/integration/datadog/ent/dd"
+	"entgo.io/ent/entc/integration/datadog/ent/enttest"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/mocktracer"
+)
+
+func TestInstrumentedClient(t *testing.T) {
+	ctx := context.Background()
+	base := enttest.Open(t, "sqlite3", "file:datadog?mode=memory&cache=shared&_fk=1")
+	defer base.Close()
+	mt := mocktracer.Start()
+	defer mt.Stop()
+
+	client := dd.NewInstrumentedClient(base, mt.(ddtrace.Tracer))
+	client.User.Create().SetName("a8m").ExecX(ctx)
+	spans := mt.FinishedSpans()
+	require.Len(t, spans, 2)
+	// Statements are traced under the span of their mutation.
+	stmt, mutation := spans[0], spans[1]
+	require.Equal(t, mutation.SpanID(), stmt.ParentID())
+	require.Contains(t, stmt.Tag(ext.ResourceName), "INSERT INTO `users`")
+	require.Equal(t, ext.SpanTypeSQL, stmt.Tag(ext.SpanType))
+	require.Equal(t, "ent.mutation", mutation.OperationName())
+	require.Equal(t, ent.TypeUser, mutation.Tag(dd.TagEntity))
+	require.Equal(t, "OpCreate", mutation.Tag(dd.TagOperation))
+	require.Equal(t, 1, mutation.Tag(dd.TagAffected))
+
+	mt.Reset()
+	client.User.Create().SetName("nati").ExecX(ctx)
+	mt.Reset()
+	n := client.User.Update().SetName("a8m").SaveX(ctx)
+	require.Equal(t, 2, n)
+	spans = mt.FinishedSpans()
+	require.Len(t, spans, 2)
+	require.Equal(t, "OpUpdate", spans[1].Tag(dd.TagOperation))
+	require.Equal(t, 2, spans[1].Tag(dd.TagAffected))
+
+	// Queries are traced, and the base client is not affected.
+	mt.Reset()
+	require.Equal(t, 2, client.User.Query().CountX(ctx))
+	spans = mt.FinishedSpans()
+	require.Len(t, spans, 1)
+	require.Equal(t, "ent.query", spans[0].OperationName())
+	require.Equal(t, 2, base.User.Query().CountX(ctx))
+	require.Len(t, mt.FinishedSpans(), 1)
+
+	// Statements of transactions are traced.
+	mt.Reset()
+	tx, err := client.Tx(ctx)
+	require.NoError(t, err)
+	tx.User.Create().SetName("alex").ExecX(ctx)
+	require.NoError(t, tx.Commit())
+	require.Len(t, mt.FinishedSpans(), 2)
+}
@@ -0,0 +1,114 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package dlq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"entgo.io/ent/entc/integration/dlq/ent"
+	"entgo.io/ent/entc/integration/dlq/ent/enttest"
+	"entgo.io/ent/entc/integration/dlq/ent/schema"
+	"entgo.io/ent/entc/integration/dlq/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonStore stores the entries in their JSON encoding.
+type jsonStore struct {
+	entries [][]byte
+}
+
+func (s *jsonStore) Put(_ context.Context, e *ent.DLQEntry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	s.entries = append(s.entries, b)
+	return nil
+}
+
+// Take returns the decoded entries, and resets the store.
+func (s *jsonStore) Take(t *testing.T) []*ent.DLQEntry {
+	entries := make([]*ent.DLQEntry, len(s.entries))
+	for i := range s.entries {
+		entries[i] = &ent.DLQEntry{}
+		require.NoError(t, json.Unmarshal(s.entries[i], entries[i]))
+	}
+	s.entries = nil
+	return entries
+}
+
+func TestReplay(t *testing.T) {
+	var (
+		store    = &jsonStore{}
+		ctx      = context.Background()
+		failCtx  = context.WithValue(ctx, schema.PublishFailedKey{}, true)
+		birthday = time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1", enttest.WithOptions(ent.DeadLetterQueue(store)))
+	defer client.Close()
+
+	pets := client.Pet.CreateBulk(client.Pet.Create().SetName("a"), client.Pet.Create().SetName("b")).SaveX(ctx)
+	friend := client.User.Create().SetName("nati").SaveX(ctx)
+	require.Empty(t, store.entries)
+
+	// Create.
+	err := client.User.Create().
+		SetName("a8m").
+		SetAge(30).
+		SetBirthday(birthday).
+		SetRole(user.RoleAdmin).
+		AddPets(pets...).
+		SetBestFriend(friend).
+		Exec(failCtx)
+	require.EqualError(t, err, "publish failed")
+	entries := store.Take(t)
+	require.Len(t, entries, 1)
+	require.Equal(t, "publish failed", entries[0].Err)
+	require.Equal(t, ent.OpCreate, entries[0].Op)
+	require.NoError(t, client.ReplayAll(ctx, entries))
+	u := client.User.Query().Where(user.Name("a8m")).OnlyX(ctx)
+	require.Equal(t, 30, u.Age)
+	require.True(t, birthday.Equal(u.Birthday))
+	require.Equal(t, user.RoleAdmin, u.Role)
+	require.Equal(t, 2, u.QueryPets().CountX(ctx))
+	require.Equal(t, friend.ID, u.QueryBestFriend().OnlyIDX(ctx))
+
+	// Update.
+	err = u.Update().
+		AddAge(1).
+		ClearBirthday().
+		RemovePets(pets[0]).
+		ClearBestFriend().
+		Exec(failCtx)
+	require.EqualError(t, err, "publish failed")
+	entries = store.Take(t)
+	require.Len(t, entries, 1)
+	require.Equal(t, ent.OpUpdateOne, entries[0].Op)
+	require.NoError(t, client.ReplayAll(ctx, entries))
+	u = client.User.GetX(ctx, u.ID)
+	require.Equal(t, 31, u.Age)
+	require.True(t, u.Birthday.IsZero())
+	require.Equal(t, []int{pets[1].ID}, u.QueryPets().IDsX(ctx))
+	require.False(t, u.QueryBestFriend().ExistX(ctx))
+
+	// Failed replays.
+	require.Error(t, client.User.UpdateOne(u).SetName("ariel").Exec(failCtx))
+	require.Error(t, client.User.UpdateOneID(friend.ID).SetName("nati2").Exec(failCtx))
+	entries = store.Take(t)
+	require.Len(t, entries, 2)
+	client.User.DeleteOneID(friend.ID).ExecX(ctx)
+	err = client.ReplayAll(ctx, entries)
+	var rerr *ent.ReplayError
+	require.True(t, errors.As(err, &rerr))
+	require.Equal(t, []*ent.DLQEntry{entries[1]}, rerr.Entries)
+	require.True(t, ent.IsNotFound(rerr.Errs[0]))
+	require.Equal(t, "ariel", client.User.GetX(ctx, u.ID).Name, "other entries are replayed")
+}
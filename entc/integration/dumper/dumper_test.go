@@ -0,0 +1,97 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package dumper
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/dumper/ent"
+	"entgo.io/ent/entc/integration/dumper/ent/dumper"
+	"entgo.io/ent/entc/integration/dumper/ent/enttest"
+	"entgo.io/ent/entc/integration/dumper/ent/user"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpSQL(t *testing.T) {
+	ctx := context.Background()
+	src := enttest.Open(t, "sqlite3", "file:dumper-src?mode=memory&cache=shared&_fk=1")
+	defer src.Close()
+
+	now := time.Date(2022, 6, 1, 10, 20, 30, 0, time.UTC)
+	a8m := src.User.Create().SetName("a8m").SetScore(1.5).SetAvatar([]byte{0xde, 0xad}).SetCreatedAt(now).SaveX(ctx)
+	nati := src.User.Create().SetName("O'Neil").SetActive(false).SetCreatedAt(now.Add(time.Hour)).SaveX(ctx)
+	src.Pet.Create().SetName("pedro").SetOwner(a8m).SaveX(ctx)
+	src.Group.Create().SetName("ent").AddUsers(a8m, nati).SaveX(ctx)
+
+	var b bytes.Buffer
+	require.NoError(t, dumper.DumpSQL(ctx, src, &b))
+	stmts := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Len(t, stmts, 6)
+	require.Contains(t, stmts, "INSERT INTO `users` (`id`, `name`, `active`, `score`, `avatar`, `created_at`) VALUES (2, 'O''Neil', 0, NULL, NULL, '2022-06-01 11:20:30+00:00');")
+	// Referenced tables are dumped before the tables that reference them.
+	index := func(prefix string) int {
+		for i, s := range stmts {
+			if strings.HasPrefix(s, prefix) {
+				return i
+			}
+		}
+		return -1
+	}
+	require.Less(t, index("INSERT INTO `users`"), index("INSERT INTO `pets`"))
+	require.Less(t, index("INSERT INTO `groups`"), index("INSERT INTO `group_users`"))
+	require.Less(t, index("INSERT INTO `users`"), index("INSERT INTO `group_users`"))
+
+	// Restore the dump into an empty database.
+	dst := enttest.Open(t, "sqlite3", "file:dumper-dst?mode=memory&cache=shared&_fk=1")
+	defer dst.Close()
+	for _, stmt := range stmts {
+		require.NoError(t, dst.Driver().Exec(ctx, stmt, []interface{}{}, nil), stmt)
+	}
+	u := dst.User.Query().Where(user.Name("a8m")).WithPets().WithGroups().OnlyX(ctx)
+	require.Equal(t, a8m.ID, u.ID)
+	require.True(t, u.Active)
+	require.Equal(t, 1.5, u.Score)
+	require.Equal(t, []byte{0xde, 0xad}, u.Avatar)
+	require.True(t, now.Equal(u.CreatedAt))
+	require.Len(t, u.Edges.Pets, 1)
+	require.Len(t, u.Edges.Groups, 1)
+	require.False(t, dst.User.GetX(ctx, nati.ID).Active)
+	require.Equal(t, 2, dst.Group.QueryUsers(u.Edges.Groups[0]).CountX(ctx))
+
+	// Join tables are dumped only if both of their sides are dumped.
+	b.Reset()
+	require.NoError(t, dumper.DumpSQL(ctx, src, &b, "User", "Pet"))
+	require.NotContains(t, b.String(), "group_users")
+	require.NotContains(t, b.String(), "INSERT INTO `groups`")
+	require.Contains(t, b.String(), "INSERT INTO `pets`")
+	require.EqualError(t, dumper.DumpSQL(ctx, src, &b, "Unknown"), `dumper: unknown entity type "Unknown"`)
+}
+
+func TestDumpSQLPostgres(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.Postgres, db)))
+
+	now := time.Date(2022, 6, 1, 10, 20, 30, 0, time.UTC)
+	mock.ExpectQuery(`SELECT "id", "name", "active", "score", "avatar", "created_at" FROM "users" ORDER BY "id"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "active", "score", "avatar", "created_at"}).
+			AddRow(1, `a8m\`, true, 1.5, []byte{0xde, 0xad}, now))
+	var b bytes.Buffer
+	require.NoError(t, dumper.DumpSQL(ctx, client, &b, "User"))
+	require.Equal(t, `INSERT INTO "users" ("id", "name", "active", "score", "avatar", "created_at") VALUES (1, 'a8m\', TRUE, 1.5, '\xdead', '2022-06-01 10:20:30+00:00');
+SELECT setval(pg_get_serial_sequence('"users"', 'id'), (SELECT MAX("id") FROM "users"));
+`, b.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,74 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package encrypt
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/encrypt/ent"
+	"entgo.io/ent/entc/integration/encrypt/ent/enttest"
+	"entgo.io/ent/entc/integration/encrypt/ent/migrate"
+	"entgo.io/ent/entc/integration/encrypt/ent/schema"
+	"entgo.io/ent/entc/integration/encrypt/ent/user"
+	"entgo.io/ent/schema/field"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncrypt(t *testing.T) {
+	ctx := context.Background()
+	drv, err := sql.Open(dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	client := enttest.NewClient(t, enttest.WithOptions(ent.Driver(drv)))
+	defer client.Close()
+
+	// Columns are big enough for the ciphertext of the largest value.
+	require.Equal(t, field.EncryptedSize(11), migrate.UsersColumns[2].Size)
+	require.Equal(t, field.EncryptedSize(255), migrate.UsersColumns[3].Size)
+
+	a8m := client.User.Create().SetName("a8m").SetSsn("123-45-6789").SaveX(ctx)
+	require.Equal(t, "123-45-6789", a8m.Ssn)
+	require.Nil(t, a8m.Note)
+	ssn, note := rawValues(t, drv, a8m.ID)
+	require.True(t, strings.HasPrefix(ssn, "v1:"))
+	require.NotContains(t, ssn, "6789")
+	require.LessOrEqual(t, int64(len(ssn)), field.EncryptedSize(11))
+	require.Empty(t, note)
+	require.Equal(t, 1, client.User.Query().Where(user.NoteIsNil()).CountX(ctx))
+
+	// Validators are applied on the plaintext values.
+	_, err = client.User.Create().SetName("nati").SetSsn("123-45-67890").Save(ctx)
+	require.Error(t, err)
+
+	// Rotate the key. Old values are still readable.
+	defer func(current int) { schema.Keys.Current = current }(schema.Keys.Current)
+	schema.Keys.Keys[2], schema.Keys.Current = bytes.Repeat([]byte{2}, 32), 2
+	require.Equal(t, "123-45-6789", client.User.GetX(ctx, a8m.ID).Ssn)
+	a8m = a8m.Update().SetNote("rotated").SaveX(ctx)
+	ssn, note = rawValues(t, drv, a8m.ID)
+	require.True(t, strings.HasPrefix(ssn, "v1:"))
+	require.True(t, strings.HasPrefix(note, "v2:"))
+	a8m = client.User.GetX(ctx, a8m.ID)
+	require.Equal(t, "123-45-6789", a8m.Ssn)
+	require.Equal(t, "rotated", *a8m.Note)
+	require.Equal(t, 1, client.User.Query().Where(user.NoteNotNil()).CountX(ctx))
+}
+
+// rawValues returns the stored values of the encrypted fields of the given user.
+func rawValues(t *testing.T, drv *sql.Driver, id int) (ssn, note string) {
+	var n *string
+	err := drv.DB().QueryRow("SELECT ssn, note FROM users WHERE id = ?", id).Scan(&ssn, &n)
+	require.NoError(t, err)
+	if n != nil {
+		note = *n
+	}
+	return ssn, note
+}
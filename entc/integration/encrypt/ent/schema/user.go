@@ -0,0 +1,57 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"bytes"
+	"fmt"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Keys holds the encryption keys of the User fields.
+var Keys = &KeyStore{
+	Current: 1,
+	Keys:    map[int][]byte{1: bytes.Repeat([]byte{1}, 32)},
+}
+
+// KeyStore is an in-memory field.KeyVersionStore.
+type KeyStore struct {
+	Current int
+	Keys    map[int][]byte
+}
+
+// CurrentKey implements the field.KeyVersionStore interface.
+func (k *KeyStore) CurrentKey() (int, []byte) { return k.Current, k.Keys[k.Current] }
+
+// Key implements the field.KeyVersionStore interface.
+func (k *KeyStore) Key(id int) ([]byte, error) {
+	key, ok := k.Keys[id]
+	if !ok {
+		return nil, fmt.Errorf("key %d was not found", id)
+	}
+	return key, nil
+}
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+		field.String("ssn").
+			MaxLen(11).
+			Sensitive().
+			EncryptVersioned(Keys),
+		field.String("note").
+			Optional().
+			Nillable().
+			EncryptVersioned(Keys),
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package entinterface
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/entc/integration/entinterface/ent"
+	"entgo.io/ent/entc/integration/entinterface/ent/enttest"
+	"entgo.io/ent/entc/integration/entinterface/ent/group"
+	"entgo.io/ent/entc/integration/entinterface/ent/membership"
+	"entgo.io/ent/entc/integration/entinterface/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntity(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:entinterface?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	u := client.User.Create().SetName("a8m").SaveX(ctx)
+	g := client.Group.Create().SetName("ent").SaveX(ctx)
+	client.Membership.Create().SetUser(u).SetGroup(g).SetRole("admin").ExecX(ctx)
+	m := client.Membership.Query().OnlyX(ctx)
+
+	entities := []ent.Entity{u, g, m}
+	labels := make([]string, len(entities))
+	for i, e := range entities {
+		labels[i] = e.GetLabel()
+	}
+	require.Equal(t, []string{user.Label, group.Label, membership.Label}, labels)
+	require.Equal(t, u.ID, u.GetID())
+	require.Equal(t, []interface{}{u.ID, g.ID}, m.GetID())
+
+	v, ok := u.GetField(user.FieldName)
+	require.True(t, ok)
+	require.Equal(t, "a8m", v)
+	v, ok = u.GetField(user.FieldID)
+	require.True(t, ok)
+	require.Equal(t, u.ID, v)
+	v, ok = u.GetField(user.FieldAge)
+	require.True(t, ok)
+	require.Nil(t, v.(*int))
+	_, ok = u.GetField("unknown")
+	require.False(t, ok)
+	v, ok = m.GetField(membership.FieldRole)
+	require.True(t, ok)
+	require.Equal(t, "admin", v)
+	_, ok = m.GetField("id")
+	require.False(t, ok)
+}
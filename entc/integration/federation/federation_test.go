@@ -0,0 +1,57 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package federation
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/entc/integration/federation/ent"
+	"entgo.io/ent/entc/integration/federation/ent/enttest"
+	"entgo.io/ent/entc/integration/federation/ent/federation"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntities(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:federation?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	u := client.User.Create().SetName("a8m").SaveX(ctx)
+	p := client.Pet.Create().SetID("pedro").SetName("Pedro").SaveX(ctx)
+	client.Card.Create().SetNumber("1234").ExecX(ctx)
+	r := federation.NewResolver(client)
+
+	// Entities are returned in the order of the representations,
+	// and entities that do not exist are resolved to nil.
+	entities, err := r.Entities(ctx, []federation.Representation{
+		{"__typename": "Pet", "id": "pedro"},
+		{"__typename": "User", "id": "1"},
+		{"__typename": "User", "id": float64(u.ID)},
+		{"__typename": "User", "id": float64(1e6)},
+		{"__typename": "Pet", "id": "xabi"},
+	})
+	require.NoError(t, err)
+	require.Len(t, entities, 5)
+	require.Equal(t, p.ID, entities[0].(*ent.Pet).ID)
+	require.Equal(t, u.ID, entities[1].(*ent.User).ID)
+	require.Equal(t, u.ID, entities[2].(*ent.User).ID)
+	require.Nil(t, entities[3])
+	require.Nil(t, entities[4])
+
+	_, err = r.Entities(ctx, []federation.Representation{{"__typename": "User", "id": "a8m"}})
+	require.Error(t, err)
+	_, err = r.Entities(ctx, []federation.Representation{{"__typename": "User"}})
+	require.EqualError(t, err, `federation: missing key "id" in User representation`)
+	// Entities with non-scalar ids (e.g. UUID) are not resolved.
+	_, err = r.Entities(ctx, []federation.Representation{{"__typename": "Card", "id": "00000000-0000-0000-0000-000000000000"}})
+	require.EqualError(t, err, "federation: unexpected entity type Card")
+
+	node, err := r.User.ResolveEntity(ctx, federation.Representation{"id": u.ID})
+	require.NoError(t, err)
+	require.Equal(t, "a8m", node.Name)
+}
@@ -0,0 +1,220 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package findorcreate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/findorcreate/ent"
+	"entgo.io/ent/entc/integration/findorcreate/ent/enttest"
+	"entgo.io/ent/entc/integration/findorcreate/ent/user"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindOrCreate(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	u1, created, err := client.User.FindOrCreate(ctx, user.Email("a8m@example.com"), func(c *ent.UserCreate) {
+		c.SetEmail("a8m@example.com").SetName("a8m")
+	})
+	require.NoError(t, err)
+	require.True(t, created)
+	u2, created, err := client.User.FindOrCreate(ctx, user.Email("a8m@example.com"), func(c *ent.UserCreate) {
+		c.SetEmail("a8m@example.com").SetName("ariel")
+	})
+	require.NoError(t, err)
+	require.False(t, created)
+	require.Equal(t, u1.ID, u2.ID)
+	require.Equal(t, "a8m", u2.Name)
+	require.Equal(t, 1, client.User.Query().CountX(ctx))
+	require.Equal(t, "a8m", u2.Update().SaveX(ctx).Name, "entity is detached from the transaction")
+
+	_, _, err = client.User.FindOrCreate(ctx, user.Email("nati@example.com"), func(c *ent.UserCreate) {})
+	require.Error(t, err, "missing required field")
+	require.Equal(t, 1, client.User.Query().CountX(ctx), "transaction was rolled back")
+}
+
+func TestFindOrCreateMySQLLock(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.MySQL, db)))
+
+	// The lock is acquired on a dedicated connection and released after the transaction is committed.
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT GET_LOCK").WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .* FROM `users`").WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name"}))
+	mock.ExpectExec("INSERT INTO `users`").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT RELEASE_LOCK").WillReturnRows(sqlmock.NewRows([]string{"release"}).AddRow(1))
+	mock.ExpectRollback()
+	u, created, err := client.User.FindOrCreate(ctx, user.Email("a8m@example.com"), func(c *ent.UserCreate) {
+		c.SetEmail("a8m@example.com")
+	})
+	require.NoError(t, err)
+	require.True(t, created)
+	require.Equal(t, 1, u.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// The lock is released after the transaction is rolled back when the operation fails.
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT GET_LOCK").WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .* FROM `users`").WillReturnError(errors.New("boom"))
+	mock.ExpectRollback()
+	mock.ExpectQuery("SELECT RELEASE_LOCK").WillReturnRows(sqlmock.NewRows([]string{"release"}).AddRow(1))
+	mock.ExpectRollback()
+	_, _, err = client.User.FindOrCreate(ctx, user.Email("a8m@example.com"), func(c *ent.UserCreate) {
+		c.SetEmail("a8m@example.com")
+	})
+	require.EqualError(t, err, "boom")
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// A lock that was not acquired is not released.
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT GET_LOCK").WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(0))
+	mock.ExpectRollback()
+	_, _, err = client.User.FindOrCreate(ctx, user.Email("a8m@example.com"), func(c *ent.UserCreate) {
+		c.SetEmail("a8m@example.com")
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "acquiring advisory lock")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindOrCreateMySQLConcurrent(t *testing.T) {
+	ctx := context.Background()
+	drv, err := sql.Open(dialect.SQLite, fmt.Sprintf("file:%s?_fk=1&_journal_mode=WAL&_busy_timeout=5000", filepath.Join(t.TempDir(), "ent.db")))
+	require.NoError(t, err)
+	defer drv.Close()
+	require.NoError(t, ent.NewClient(ent.Driver(drv)).Schema.Create(ctx))
+	locks := &namedLocks{held: make(map[string]chan struct{}), contended: make(chan struct{}, 1)}
+	client := ent.NewClient(ent.Driver(&mysqlDriver{Driver: drv, locks: locks}))
+
+	var (
+		wg      sync.WaitGroup
+		created = make([]bool, 2)
+		errs    = make([]error, 2)
+	)
+	for i := range created {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, created[i], errs[i] = client.User.FindOrCreate(ctx, user.Email("a8m@example.com"), func(c *ent.UserCreate) {
+				// Hold the lock until the other caller is waiting for it.
+				select {
+				case <-locks.contended:
+				case <-time.After(5 * time.Second):
+				}
+				c.SetEmail("a8m@example.com")
+			})
+		}(i)
+	}
+	wg.Wait()
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	require.True(t, created[0] != created[1], "exactly one caller creates the entity")
+	require.Equal(t, 1, client.User.Query().CountX(ctx))
+}
+
+// mysqlDriver executes the MySQL code path on top of SQLite, and emulates the named locks of MySQL.
+type mysqlDriver struct {
+	dialect.Driver
+	locks *namedLocks
+}
+
+func (*mysqlDriver) Dialect() string { return dialect.MySQL }
+
+func (d *mysqlDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlTx{Tx: tx, locks: d.locks}, nil
+}
+
+type mysqlTx struct {
+	dialect.Tx
+	locks *namedLocks
+}
+
+func (tx *mysqlTx) Query(ctx context.Context, query string, args, v interface{}) error {
+	switch {
+	case strings.HasPrefix(query, "SELECT GET_LOCK"):
+		tx.locks.lock(args.([]interface{})[0].(string))
+	case strings.HasPrefix(query, "SELECT RELEASE_LOCK"):
+		tx.locks.unlock(args.([]interface{})[0].(string))
+	default:
+		return tx.Tx.Query(ctx, query, args, v)
+	}
+	return tx.Tx.Query(ctx, "SELECT 1", []interface{}{}, v)
+}
+
+// namedLocks emulates the MySQL GET_LOCK and RELEASE_LOCK functions.
+type namedLocks struct {
+	mu        sync.Mutex
+	held      map[string]chan struct{}
+	contended chan struct{}
+}
+
+func (l *namedLocks) lock(name string) {
+	l.mu.Lock()
+	c, ok := l.held[name]
+	if !ok {
+		c = make(chan struct{}, 1)
+		l.held[name] = c
+	}
+	l.mu.Unlock()
+	select {
+	case c <- struct{}{}:
+	default:
+		l.contended <- struct{}{}
+		c <- struct{}{}
+	}
+}
+
+func (l *namedLocks) unlock(name string) {
+	l.mu.Lock()
+	c := l.held[name]
+	l.mu.Unlock()
+	<-c
+	// Give the waiting session a head start, in order to expose
+	// locks that are released before the transaction is committed.
+	time.Sleep(100 * time.Millisecond)
+}
+
+func TestFindOrCreatePostgresLock(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.Postgres, db)))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT pg_advisory_xact_lock").WillReturnRows(sqlmock.NewRows([]string{"lock"}).AddRow(nil))
+	mock.ExpectQuery(`SELECT .* FROM "users"`).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name"}).AddRow(1, "a8m@example.com", "a8m"))
+	mock.ExpectCommit()
+	u, created, err := client.User.FindOrCreate(ctx, user.Email("a8m@example.com"), func(c *ent.UserCreate) {
+		c.SetEmail("a8m@example.com")
+	})
+	require.NoError(t, err)
+	require.False(t, created)
+	require.Equal(t, "a8m", u.Name)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
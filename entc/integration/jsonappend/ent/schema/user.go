@@ -0,0 +1,29 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Aliases is a named slice type that is stored as a JSON array.
+type Aliases []string
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.Strings("tags").
+			Optional(),
+		field.JSON("aliases", Aliases{}),
+		field.Ints("scores").
+			Optional(),
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package jsonappend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/jsonappend/ent"
+	"entgo.io/ent/entc/integration/jsonappend/ent/schema"
+	"entgo.io/ent/entc/integration/jsonappend/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppend(t *testing.T) {
+	ctx := context.Background()
+	drv, err := sql.Open(dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	var updates []string
+	client := ent.NewClient(ent.Driver(dialect.DebugWithContext(drv, func(_ context.Context, v ...interface{}) {
+		if s := fmt.Sprint(v...); strings.Contains(s, "UPDATE") {
+			updates = append(updates, s)
+		}
+	})))
+	defer client.Close()
+	require.NoError(t, client.Schema.Create(ctx))
+
+	u := client.User.Create().SetAliases(schema.Aliases{"a8m"}).SaveX(ctx)
+	u = u.Update().AppendTags("a").AppendTags("b").AppendAliases("ariel").AppendScores(1).SaveX(ctx)
+	require.Equal(t, []string{"a", "b"}, u.Tags, "NULL columns are treated as empty arrays")
+	require.Equal(t, schema.Aliases{"a8m", "ariel"}, u.Aliases)
+	require.Equal(t, []int{1}, u.Scores)
+
+	client.User.Update().Where(user.ID(u.ID)).AppendTags("c").ExecX(ctx)
+	require.Equal(t, []string{"a", "b", "c"}, client.User.GetX(ctx, u.ID).Tags)
+
+	// Appending after setting the field appends to the new value.
+	updates = nil
+	u = u.Update().SetTags([]string{"x"}).AppendTags("y").SetAliases(schema.Aliases{}).AppendAliases("nati").SaveX(ctx)
+	require.Equal(t, []string{"x", "y"}, u.Tags)
+	require.Equal(t, schema.Aliases{"nati"}, u.Aliases)
+	require.Len(t, updates, 1)
+	require.Equal(t, 1, strings.Count(updates[0], "`tags` = "), "the column is set once")
+	require.Equal(t, 1, strings.Count(updates[0], "`aliases` = "), "the column is set once")
+
+	// Appending after clearing the field sets it to the appended elements.
+	u = u.Update().ClearTags().AppendTags("z").ClearScores().SaveX(ctx)
+	require.Equal(t, []string{"z"}, u.Tags)
+	require.Empty(t, u.Scores)
+	u = client.User.GetX(ctx, u.ID)
+	require.Equal(t, []string{"z"}, u.Tags)
+	require.Nil(t, u.Scores)
+
+	// Setting the field after appending to it discards the appended elements.
+	u = u.Update().AppendTags("discarded").SetTags([]string{"w"}).SaveX(ctx)
+	require.Equal(t, []string{"w"}, client.User.GetX(ctx, u.ID).Tags)
+}
@@ -0,0 +1,121 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package memcached
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"entgo.io/ent/entc/integration/memcached/ent/cache"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemcachedExpiration(t *testing.T) {
+	ctx := context.Background()
+	srv := newServer(t)
+	backend := cache.NewMemcachedBackend(memcache.New(srv.addr))
+
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		want func() int64
+	}{
+		{
+			name: "no expiration",
+			want: func() int64 { return 0 },
+		},
+		{
+			name: "sub-second",
+			ttl:  500 * time.Millisecond,
+			want: func() int64 { return 1 },
+		},
+		{
+			name: "partial seconds",
+			ttl:  1500 * time.Millisecond,
+			want: func() int64 { return 2 },
+		},
+		{
+			name: "relative",
+			ttl:  time.Hour,
+			want: func() int64 { return 3600 },
+		},
+		{
+			name: "30 days",
+			ttl:  30 * 24 * time.Hour,
+			want: func() int64 { return 30 * 24 * 3600 },
+		},
+		{
+			name: "absolute",
+			ttl:  60 * 24 * time.Hour,
+			want: func() int64 { return time.Now().Add(60 * 24 * time.Hour).Unix() },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, backend.Set(ctx, "key", []byte("value"), tt.ttl))
+			require.InDelta(t, tt.want(), <-srv.exptimes, 1)
+		})
+	}
+}
+
+// server is a fake Memcached server that records the
+// expiration times of the items that are set.
+type server struct {
+	addr     string
+	exptimes chan int64
+}
+
+func newServer(t *testing.T) *server {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	s := &server{addr: l.Addr().String(), exptimes: make(chan int64, 1)}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+// serve handles the "set <key> <flags> <exptime> <bytes>" commands.
+func (s *server) serve(conn net.Conn) {
+	defer conn.Close()
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+	for {
+		line, err := rw.ReadString('\n')
+		if err != nil {
+			return
+		}
+		var (
+			key            string
+			flags, exptime int64
+			size           int
+		)
+		if _, err := fmt.Sscanf(strings.TrimSpace(line), "set %s %d %d %d", &key, &flags, &exptime, &size); err != nil {
+			fmt.Fprint(rw, "ERROR\r\n")
+		} else if _, err := io.CopyN(io.Discard, rw, int64(size)+2); err != nil {
+			return
+		} else {
+			s.exptimes <- exptime
+			fmt.Fprint(rw, "STORED\r\n")
+		}
+		if err := rw.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Code generated by ent, DO NOT EDIT.
+
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	_ "entgo.io/ent/entc/integration/middleware/ent"
+	_ "entgo.io/ent/entc/integration/middleware/ent/runtime"
+	"entgo.io/ent/entc/integration/middleware/ent/user"
+)
+
+// UserCreate is the request body for creating User entities. Fields
+// that were not set in the request body are nil.
+type UserCreate struct {
+	Name      *string    `json:"name,omitempty"`
+	Age       *int       `json:"age,omitempty"`
+	Email     *string    `json:"email,omitempty"`
+	Role      *user.Role `json:"role,omitempty"`
+	Tags      *[]string  `json:"tags,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// Validate runs the validators of the User fields on the request body, and
+// checks that all required fields (that have no default value) were set.
+func (b *UserCreate) Validate() error {
+	if b.Name == nil {
+		return fmt.Errorf(`missing required field "User.name"`)
+	}
+	if v := b.Name; v != nil {
+		if err := user.NameValidator(*v); err != nil {
+			return fmt.Errorf(`validator failed for field "User.name": %w`, err)
+		}
+	}
+	if b.Age == nil {
+		return fmt.Errorf(`missing required field "User.age"`)
+	}
+	if v := b.Age; v != nil {
+		if err := user.AgeValidator(*v); err != nil {
+			return fmt.Errorf(`validator failed for field "User.age": %w`, err)
+		}
+	}
+	if v := b.Email; v != nil {
+		if err := user.EmailValidator(*v); err != nil {
+			return fmt.Errorf(`validator failed for field "User.email": %w`, err)
+		}
+	}
+	if v := b.Role; v != nil {
+		if err := user.RoleValidator(*v); err != nil {
+			return fmt.Errorf(`validator failed for field "User.role": %w`, err)
+		}
+	}
+	return nil
+}
+
+// ValidateUserCreate returns a middleware that decodes the JSON request body into a UserCreate and
+// validates it. Requests with a malformed body fail with 400 Bad Request, and requests that fail the
+// validation fail with 422 Unprocessable Entity. The request body is passed as-is to the next handler.
+//
+//	mux.Handle("/users", middleware.ValidateUserCreate(handler))
+func ValidateUserCreate(next http.Handler) http.Handler {
+	return validate(next, func() interface{ Validate() error } { return &UserCreate{} })
+}
+
+// validate returns a middleware that decodes the request body into the value
+// returned by newBody, and validates it before calling the next handler.
+func validate(next http.Handler, newBody func() interface{ Validate() error }) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("reading request body: %v", err), http.StatusBadRequest)
+			return
+		}
+		body := newBody()
+		if err := json.Unmarshal(buf, body); err != nil {
+			http.Error(w, fmt.Sprintf("decoding request body: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := body.Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(buf))
+		next.ServeHTTP(w, r)
+	})
+}
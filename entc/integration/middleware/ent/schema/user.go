@@ -0,0 +1,39 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"regexp"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty().
+			MaxLen(10),
+		field.Int("age").
+			Positive(),
+		field.String("email").
+			Optional().
+			Match(regexp.MustCompile("^[^@]+@[^@]+$")),
+		field.Enum("role").
+			Values("admin", "user").
+			Default("user"),
+		field.Strings("tags").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/entc/integration/middleware/ent/middleware"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateUserCreate(t *testing.T) {
+	var got string
+	h := middleware.ValidateUserCreate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		got = string(b)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	tests := []struct {
+		body string
+		code int
+		err  string
+	}{
+		{
+			body: `{"name": "a8m", "age": 30}`,
+			code: http.StatusCreated,
+		},
+		{
+			body: `{"name": "a8m", "age": 30, "email": "a8m@example.com", "role": "admin", "tags": ["ent"], "created_at": "2022-06-01T10:20:30Z"}`,
+			code: http.StatusCreated,
+		},
+		{
+			body: `{"name": "a8m"`,
+			code: http.StatusBadRequest,
+			err:  "decoding request body",
+		},
+		{
+			body: `{"name": "a8m", "age": "30"}`,
+			code: http.StatusBadRequest,
+			err:  "decoding request body",
+		},
+		{
+			body: `{"age": 30}`,
+			code: http.StatusUnprocessableEntity,
+			err:  `missing required field "User.name"`,
+		},
+		{
+			body: `{"name": "a8m"}`,
+			code: http.StatusUnprocessableEntity,
+			err:  `missing required field "User.age"`,
+		},
+		{
+			body: `{"name": "", "age": 30}`,
+			code: http.StatusUnprocessableEntity,
+			err:  `validator failed for field "User.name"`,
+		},
+		{
+			body: `{"name": "a8m", "age": -1}`,
+			code: http.StatusUnprocessableEntity,
+			err:  `validator failed for field "User.age"`,
+		},
+		{
+			body: `{"name": "a8m", "age": 30, "email": "a8m"}`,
+			code: http.StatusUnprocessableEntity,
+			err:  `validator failed for field "User.email"`,
+		},
+		{
+			body: `{"name": "a8m", "age": 30, "role": "owner"}`,
+			code: http.StatusUnprocessableEntity,
+			err:  `validator failed for field "User.role"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			got = ""
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.body)))
+			require.Equal(t, tt.code, rec.Code)
+			if tt.err != "" {
+				require.Contains(t, rec.Body.String(), tt.err)
+				require.Empty(t, got, "next handler should not be called")
+			} else {
+				require.Equal(t, tt.body, got, "request body should be passed as-is")
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package paginatedlist
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"entgo.io/ent/entc/integration/paginatedlist/ent"
+	"entgo.io/ent/entc/integration/paginatedlist/ent/enttest"
+	"entgo.io/ent/entc/integration/paginatedlist/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginatedList(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:paginatedlist?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	builders := make([]*ent.UserCreate, 5)
+	for i := range builders {
+		builders[i] = client.User.Create().SetName(fmt.Sprintf("u%d", i)).SetAge(i)
+	}
+	users := client.User.CreateBulk(builders...).SaveX(ctx)
+	names := func(us []*ent.User) (names []string) {
+		for _, u := range us {
+			names = append(names, u.Name)
+		}
+		return names
+	}
+
+	// Entities are ordered by their ids by default.
+	res, err := client.User.PaginatedList(ctx, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"u0", "u1"}, names(res.Items))
+	require.Equal(t, 5, res.Total)
+	require.True(t, res.HasNextPage)
+	require.Equal(t, users[0].Age, res.Items[0].Age)
+	res, err = client.User.PaginatedList(ctx, 3, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"u4"}, names(res.Items))
+	require.Equal(t, 5, res.Total)
+	require.False(t, res.HasNextPage)
+
+	// The total count is returned for pages that are out of range.
+	res, err = client.User.PaginatedList(ctx, 4, 2)
+	require.NoError(t, err)
+	require.Empty(t, res.Items)
+	require.Equal(t, 5, res.Total)
+	require.False(t, res.HasNextPage)
+
+	res, err = client.User.PaginatedList(ctx, 1, 2,
+		ent.ListWhere(user.AgeGT(1)),
+		ent.ListOrder(ent.Desc(user.FieldName)),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"u4", "u3"}, names(res.Items))
+	require.Equal(t, 3, res.Total)
+	require.True(t, res.HasNextPage)
+	res, err = client.User.PaginatedList(ctx, 1, 2, ent.ListWhere(user.AgeGT(10)))
+	require.NoError(t, err)
+	require.Empty(t, res.Items)
+	require.Zero(t, res.Total)
+	require.False(t, res.HasNextPage)
+
+	_, err = client.User.PaginatedList(ctx, 0, 2)
+	require.EqualError(t, err, "ent: invalid page (0) or perPage (2) argument for User.PaginatedList")
+	_, err = client.User.PaginatedList(ctx, 1, 0)
+	require.Error(t, err)
+}
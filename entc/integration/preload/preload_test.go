@@ -0,0 +1,92 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package preload
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/entc/integration/preload/ent"
+	"entgo.io/ent/entc/integration/preload/ent/pet"
+	"entgo.io/ent/entc/integration/preload/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInlineJSON(t *testing.T) {
+	ctx := context.Background()
+	drv, err := sql.Open(dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	var queries int
+	client := ent.NewClient(ent.Driver(dialect.DebugWithContext(drv, func(context.Context, ...interface{}) {
+		queries++
+	})))
+	defer client.Close()
+	require.NoError(t, client.Schema.Create(ctx))
+
+	now := time.Now().Truncate(time.Microsecond)
+	a8m := client.User.Create().
+		SetName("a8m").
+		SetAge(30).
+		SetScore(1.5).
+		SetActive(true).
+		SetCreatedAt(now).
+		SetRole(user.RoleAdmin).
+		SetTags([]string{"a", "b"}).
+		SetPassword("secret").
+		SaveX(ctx)
+	nati := client.User.Create().
+		SetName("nati").
+		SetAge(28).
+		SetScore(2).
+		SetActive(false).
+		SetCreatedAt(now).
+		SetRole(user.RoleUser).
+		SetNickname("nat").
+		SetPassword("secret").
+		SaveX(ctx)
+	client.Pet.Create().SetName("pedro").SetOwner(a8m).ExecX(ctx)
+	client.Pet.Create().SetName("xabi").SetOwner(nati).ExecX(ctx)
+	client.Pet.Create().SetName("coco").ExecX(ctx)
+
+	queries = 0
+	pets := client.Pet.Query().Order(ent.Asc(pet.FieldName)).AllX(ctx)
+	require.Equal(t, 1, queries, "owners are loaded by the pets query")
+	require.Len(t, pets, 3)
+
+	_, err = pets[0].Edges.OwnerOrErr()
+	require.True(t, ent.IsNotFound(err), "coco has no owner")
+
+	owner, err := pets[1].Edges.OwnerOrErr()
+	require.NoError(t, err)
+	require.Equal(t, a8m.ID, owner.ID)
+	require.Equal(t, "a8m", owner.Name)
+	require.Equal(t, 30, owner.Age)
+	require.Equal(t, 1.5, owner.Score)
+	require.True(t, owner.Active)
+	require.True(t, now.Equal(owner.CreatedAt), "%v != %v", now, owner.CreatedAt)
+	require.Equal(t, user.RoleAdmin, owner.Role)
+	require.Equal(t, []string{"a", "b"}, owner.Tags)
+	require.Nil(t, owner.Nickname)
+	require.Equal(t, "secret", owner.Password)
+
+	owner, err = pets[2].Edges.OwnerOrErr()
+	require.NoError(t, err)
+	require.Equal(t, nati.ID, owner.ID)
+	require.Equal(t, 2.0, owner.Score)
+	require.False(t, owner.Active)
+	require.Empty(t, owner.Tags)
+	require.Equal(t, "nat", *owner.Nickname)
+	require.Equal(t, 1, owner.QueryPets().CountX(ctx), "preloaded entities are attached to the client")
+
+	queries = 0
+	owner = client.Pet.Query().Where(pet.Name("xabi")).OnlyX(ctx).Edges.Owner
+	require.Equal(t, 1, queries)
+	require.Equal(t, nati.ID, owner.ID)
+}
@@ -0,0 +1,38 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package querybench
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The generated benchmarks run only on Postgres (see ENT_BENCH_DSN), and are
+// compiled as part of the ent package tests. This test checks their shapes.
+func TestBenchmarks(t *testing.T) {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), "ent", func(fi fs.FileInfo) bool {
+		return strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	require.NoError(t, err)
+	require.Contains(t, pkgs, "ent")
+	var names []string
+	for _, f := range pkgs["ent"].Files {
+		for _, d := range f.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok && strings.HasPrefix(fn.Name.Name, "Benchmark") {
+				names = append(names, fn.Name.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	// Nillable unique fields (nickname) and non-unique fields (name) are not benchmarked.
+	require.Equal(t, []string{"BenchmarkUserQueryByEmail", "BenchmarkUserQueryByID", "BenchmarkUserQueryByToken", "BenchmarkUserQueryList"}, names)
+}
@@ -0,0 +1,51 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/entc/integration/registry/ent"
+	"entgo.io/ent/entc/integration/registry/ent/enttest"
+	"entgo.io/ent/entc/integration/registry/ent/registry"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:registry?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	set := registry.ClientSet(client)
+	require.Equal(t, []string{"Group", "User"}, set.Types())
+	registry.Register("core", set)
+	registry.Register("plugin", registry.NewEntityClientSet(map[string]interface{}{
+		"Member": client.User,
+	}))
+	require.Equal(t, []string{"core", "plugin"}, registry.All())
+	require.Panics(t, func() { registry.Register("core", set) })
+	require.Panics(t, func() { registry.Register("nil", nil) })
+
+	core, ok := registry.Lookup("core")
+	require.True(t, ok)
+	c, ok := core.Client("User")
+	require.True(t, ok)
+	uc, ok := c.(*ent.UserClient)
+	require.True(t, ok)
+	u := uc.Create().SetName("a8m").SaveX(ctx)
+
+	plugin, ok := registry.Lookup("plugin")
+	require.True(t, ok)
+	c, ok = plugin.Client("Member")
+	require.True(t, ok)
+	require.Equal(t, u.ID, c.(*ent.UserClient).Query().OnlyIDX(ctx))
+	_, ok = plugin.Client("Group")
+	require.False(t, ok)
+	_, ok = registry.Lookup("unknown")
+	require.False(t, ok)
+}
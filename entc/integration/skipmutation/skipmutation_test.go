@@ -0,0 +1,70 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package skipmutation
+
+import (
+	"context"
+	"testing"
+
+	entgo "entgo.io/ent"
+	"entgo.io/ent/entc/integration/skipmutation/ent"
+	"entgo.io/ent/entc/integration/skipmutation/ent/enttest"
+	"entgo.io/ent/entc/integration/skipmutation/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+// skipKey marks contexts of mutations that should be skipped.
+type skipKey struct{}
+
+func TestErrSkip(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:skipmutation?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	var executed int
+	client.Use(func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if ctx.Value(skipKey{}) != nil {
+				return nil, entgo.ErrSkip
+			}
+			executed++
+			return next.Mutate(ctx, m)
+		})
+	})
+	a8m := client.User.Create().SetName("a8m").SetAge(30).SaveX(ctx)
+	require.Equal(t, 1, executed)
+	skip := context.WithValue(ctx, skipKey{}, true)
+
+	// Skipped mutations succeed without executing any statement.
+	n, err := client.User.Update().SetAge(31).Save(skip)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	u, err := client.User.UpdateOne(a8m).SetName("nati").Save(skip)
+	require.NoError(t, err)
+	require.Equal(t, a8m.ID, u.ID)
+	require.Equal(t, "a8m", u.Name, "the current state of the entity should be returned")
+	n, err = client.User.Delete().Where(user.Name("a8m")).Exec(skip)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.NoError(t, client.User.DeleteOne(a8m).Exec(skip))
+	require.Equal(t, 1, executed)
+	require.Equal(t, 30, client.User.GetX(ctx, a8m.ID).Age)
+
+	// Skipped updates of missing entities return a not-found error.
+	_, err = client.User.UpdateOneID(a8m.ID + 1).SetName("nati").Save(skip)
+	require.True(t, ent.IsNotFound(err))
+
+	// Create operations do not support skipping.
+	err = client.User.Create().SetName("nati").SetAge(28).Exec(skip)
+	require.ErrorIs(t, err, entgo.ErrSkip)
+	require.Equal(t, 1, client.User.Query().CountX(ctx))
+
+	// Mutations are executed if the hook does not skip them.
+	n = client.User.Update().SetAge(31).SaveX(ctx)
+	require.Equal(t, 1, n)
+	require.Equal(t, 2, executed)
+}
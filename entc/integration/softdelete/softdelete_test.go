@@ -0,0 +1,179 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package softdelete
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/entc/integration/softdelete/ent"
+	"entgo.io/ent/entc/integration/softdelete/ent/enttest"
+	"entgo.io/ent/entc/integration/softdelete/ent/post"
+	"entgo.io/ent/entc/integration/softdelete/ent/predicate"
+	"entgo.io/ent/entc/integration/softdelete/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCascade(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:cascade?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	a8m := client.User.Create().SetName("a8m").SaveX(ctx)
+	nati := client.User.Create().SetName("nati").SetParent(a8m).SaveX(ctx)
+	alex := client.User.Create().SetName("alex").SetParent(nati).SaveX(ctx)
+	client.Post.Create().SetTitle("a8m-1").SetAuthor(a8m).ExecX(ctx)
+	client.Post.Create().SetTitle("nati-1").SetAuthor(nati).ExecX(ctx)
+
+	// The soft-delete is cascaded to the direct entities of the edges, including the same type.
+	client.User.DeleteOne(a8m).ExecX(ctx)
+	require.Equal(t, []string{"alex"}, client.User.Query().Select(user.FieldName).StringsX(ctx))
+	require.Equal(t, []string{"nati-1"}, client.Post.Query().Select(post.FieldTitle).StringsX(ctx))
+
+	// Deleted entities are not affected again.
+	err := client.User.DeleteOne(a8m).Exec(ctx)
+	require.True(t, ent.IsNotFound(err))
+	n, err := client.User.Delete().Where(user.NameIn("nati", "alex")).Exec(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Zero(t, client.User.Query().CountX(ctx))
+	require.Equal(t, alex.ID, client.User.Query().WithDeleted().Where(user.ID(alex.ID), user.DeleteTimeNotNil()).OnlyIDX(ctx))
+
+	// Parents and their children are counted once, when they are deleted together.
+	p := client.User.Create().SetName("p").SaveX(ctx)
+	client.User.Create().SetName("c").SetParent(p).ExecX(ctx)
+	n, err = client.User.Delete().Where(user.NameIn("p", "c")).Exec(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	// The cascade is executed in the transaction of the builder.
+	tx, err := client.Tx(ctx)
+	require.NoError(t, err)
+	u := tx.User.Create().SetName("u").SaveX(ctx)
+	tx.Post.Create().SetTitle("u-1").SetAuthor(u).ExecX(ctx)
+	tx.User.DeleteOne(u).ExecX(ctx)
+	require.NoError(t, tx.Rollback())
+	require.Zero(t, client.Post.Query().WithDeleted().Where(post.Title("u-1")).CountX(ctx))
+}
+
+func TestQuery(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:query?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	a8m := client.User.Create().SetName("a8m").SaveX(ctx)
+	nati := client.User.Create().SetName("nati").SaveX(ctx)
+	client.Post.Create().SetTitle("a8m-1").SetAuthor(a8m).ExecX(ctx)
+	deleted := client.Post.Create().SetTitle("a8m-2").SetAuthor(a8m).SaveX(ctx)
+	client.Post.DeleteOne(deleted).ExecX(ctx)
+	client.User.DeleteOne(nati).ExecX(ctx)
+
+	// Soft-deleted entities are filtered out of queries, traversals and eager-loading.
+	require.Equal(t, 1, client.User.Query().CountX(ctx))
+	require.False(t, client.User.Query().Where(user.Name("nati")).ExistX(ctx))
+	require.Equal(t, []string{"a8m"}, client.User.Query().Select(user.FieldName).StringsX(ctx))
+	_, err := client.User.Get(ctx, nati.ID)
+	require.True(t, ent.IsNotFound(err))
+	require.Equal(t, []string{"a8m-1"}, a8m.QueryPosts().Select(post.FieldTitle).StringsX(ctx))
+	require.Equal(t, []string{"a8m-1"}, client.User.Query().QueryPosts().Select(post.FieldTitle).StringsX(ctx))
+	u := client.User.Query().Where(user.ID(a8m.ID)).WithPosts().OnlyX(ctx)
+	require.Len(t, u.Edges.Posts, 1)
+
+	// WithDeleted includes them, also in clones of the query.
+	require.Equal(t, 2, client.User.Query().WithDeleted().CountX(ctx))
+	require.Equal(t, 2, client.User.Query().WithDeleted().Clone().CountX(ctx))
+	require.Equal(t, nati.ID, client.User.Query().WithDeleted().Where(user.DeleteTimeNotNil()).OnlyIDX(ctx))
+	require.Equal(t, 2, a8m.QueryPosts().WithDeleted().CountX(ctx))
+	u = client.User.Query().Where(user.ID(a8m.ID)).WithPosts(func(q *ent.PostQuery) { q.WithDeleted() }).OnlyX(ctx)
+	require.Len(t, u.Edges.Posts, 2)
+}
+
+func TestBulkDelete(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:bulkdelete?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	a8m := client.User.Create().SetName("a8m").SaveX(ctx)
+	nati := client.User.Create().SetName("nati").SaveX(ctx)
+	alex := client.User.Create().SetName("alex").SaveX(ctx)
+	client.Post.Create().SetTitle("a8m-1").SetAuthor(a8m).ExecX(ctx)
+	client.User.DeleteOne(alex).ExecX(ctx)
+
+	// Missing and already deleted ids are reported as not found.
+	deleted, notFound, err := client.User.BulkDelete(ctx, []int{a8m.ID, nati.ID, alex.ID, alex.ID + 100})
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+	require.Equal(t, []int{alex.ID, alex.ID + 100}, notFound)
+	require.Zero(t, client.User.Query().CountX(ctx))
+	require.Zero(t, client.Post.Query().CountX(ctx), "soft-delete should be cascaded to the posts")
+	require.Equal(t, 3, client.User.Query().WithDeleted().CountX(ctx))
+
+	// A second call does not affect the deleted entities.
+	deleted, notFound, err = client.User.BulkDelete(ctx, []int{a8m.ID, nati.ID})
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+	require.Equal(t, []int{a8m.ID, nati.ID}, notFound)
+
+	deleted, notFound, err = client.User.BulkDelete(ctx, nil)
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+	require.Empty(t, notFound)
+}
+
+func TestSoftDeleteAll(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:softdeleteall?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	a8m := client.User.Create().SetName("a8m").SaveX(ctx)
+	client.Post.Create().SetTitle("a8m-1").SetAuthor(a8m).ExecX(ctx)
+	client.Post.Create().SetTitle("a8m-2").SetAuthor(a8m).ExecX(ctx)
+
+	// The predicates of the caller are not modified, even if their slice has a spare capacity.
+	ps := make([]predicate.Post, 1, 2)
+	ps[0] = post.Title("a8m-1")
+	n, err := client.Post.SoftDeleteAll(ctx, ps...)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Nil(t, ps[:2][1])
+
+	n, err = client.Post.SoftDeleteAll(ctx, ps...)
+	require.NoError(t, err)
+	require.Zero(t, n, "already deleted entities are not affected")
+	require.Equal(t, []string{"a8m-2"}, client.Post.Query().Select(post.FieldTitle).StringsX(ctx))
+}
+
+func TestReload(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:reload?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	a8m := client.User.Create().SetName("a8m").SaveX(ctx)
+	nati := client.User.Create().SetName("nati").SaveX(ctx)
+	p := client.Post.Create().SetTitle("a8m-1").SetAuthor(a8m).SaveX(ctx)
+	p = client.Post.Query().Where(post.ID(p.ID)).WithAuthor().OnlyX(ctx)
+	require.Equal(t, a8m.ID, p.AuthorID())
+	require.Equal(t, a8m.ID, p.Edges.Author.ID)
+
+	// Fields, foreign-keys and edges are refreshed in-place.
+	client.Post.UpdateOneID(p.ID).SetTitle("nati-1").SetAuthor(nati).ExecX(ctx)
+	stale := p
+	require.NoError(t, client.Post.Reload(ctx, p))
+	require.Same(t, stale, p)
+	require.Equal(t, "nati-1", p.Title)
+	require.Equal(t, nati.ID, p.AuthorID())
+	_, err := p.Edges.AuthorOrErr()
+	require.True(t, ent.IsNotLoaded(err), "stale edges should be reset")
+	require.Equal(t, nati.ID, p.QueryAuthor().OnlyIDX(ctx))
+
+	// The entity is not modified if it was not found.
+	client.Post.DeleteOne(p).ExecX(ctx)
+	err = client.Post.Reload(ctx, p)
+	require.True(t, ent.IsNotFound(err))
+	require.Equal(t, "nati-1", p.Title)
+	require.Nil(t, p.DeleteTime)
+}
@@ -0,0 +1,70 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package traversal
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/entc/integration/traversal/ent"
+	"entgo.io/ent/entc/integration/traversal/ent/enttest"
+	"entgo.io/ent/entc/integration/traversal/ent/graph"
+	"entgo.io/ent/entc/integration/traversal/ent/pet"
+	"entgo.io/ent/entc/integration/traversal/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraversal(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:traversal?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	a8m := client.User.Create().SetName("a8m").SetAge(30).SaveX(ctx)
+	nati := client.User.Create().SetName("nati").SetAge(31).AddFriends(a8m).SaveX(ctx)
+	alex := client.User.Create().SetName("alex").SetAge(20).AddFriends(a8m).SaveX(ctx)
+	client.Pet.Create().SetName("pedro").SetOwner(nati).ExecX(ctx)
+	client.Pet.Create().SetName("xabi").SetOwner(nati).ExecX(ctx)
+	client.Pet.Create().SetName("luna").SetOwner(alex).ExecX(ctx)
+
+	// Traversals that end with the start entity.
+	users, err := graph.From(a8m).CollectUsers(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*ent.User{a8m}, users)
+
+	// Multi-hop traversals with filters.
+	pets, err := graph.From(a8m).
+		Traverse("friends", user.AgeGT(30)).
+		Traverse("pets", pet.NameNEQ("xabi")).
+		CollectPets(ctx)
+	require.NoError(t, err)
+	require.Len(t, pets, 1)
+	require.Equal(t, "pedro", pets[0].Name)
+
+	owners, err := graph.From(a8m).
+		Traverse("friends").
+		Traverse("pets").
+		Traverse("owner").
+		CollectUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, owners, 2)
+
+	v, err := graph.From(a8m).Traverse("friends").Collect(ctx)
+	require.NoError(t, err)
+	require.IsType(t, []*ent.User{}, v)
+	require.Len(t, v, 2)
+
+	// Errors are deferred to the Collect methods.
+	_, err = graph.From(a8m).Traverse("unknown").Traverse("pets").CollectPets(ctx)
+	require.EqualError(t, err, `ent/graph: unknown edge "unknown" for step *ent.User`)
+	_, err = graph.From(a8m).Traverse("pets").CollectUsers(ctx)
+	require.EqualError(t, err, "ent/graph: traversal ends with *ent.PetQuery and not with User entities")
+	g := client.Group.Create().SetName("ent").SaveX(ctx)
+	_, err = graph.From(g).Traverse("users").CollectUsers(ctx)
+	require.EqualError(t, err, `ent/graph: unknown edge "users" for step *ent.Group`)
+	_, err = graph.From(a8m.Name).Collect(ctx)
+	require.EqualError(t, err, "ent/graph: unexpected start entity type string")
+}
@@ -0,0 +1,76 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package unlogged
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+	"entgo.io/ent/entc/integration/unlogged/ent"
+	"entgo.io/ent/entc/integration/unlogged/ent/enttest"
+	"entgo.io/ent/entc/integration/unlogged/ent/user"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnloggedClient(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	client.User.Create().SetName("a8m").SaveX(ctx)
+	u := client.UnloggedUser().Create().SetName("nati").SaveX(ctx)
+	require.Equal(t, []string{"a8m"}, client.User.Query().Select(user.FieldName).StringsX(ctx))
+	require.Equal(t, []string{"nati"}, client.UnloggedUser().Query().Select(user.FieldName).StringsX(ctx))
+
+	client.UnloggedUser().UpdateOne(u).SetName("ariel").ExecX(ctx)
+	require.Equal(t, "ariel", client.UnloggedUser().GetX(ctx, u.ID).Name)
+	require.Equal(t, "a8m", client.User.Query().OnlyX(ctx).Name)
+
+	tx, err := client.Tx(ctx)
+	require.NoError(t, err)
+	tx.Client().UnloggedUser().Create().SetName("a8m").ExecX(ctx)
+	require.NoError(t, tx.Commit())
+	require.Equal(t, 2, client.UnloggedUser().Query().CountX(ctx))
+	require.Equal(t, 1, client.User.Query().CountX(ctx))
+
+	n := client.UnloggedUser().Delete().ExecX(ctx)
+	require.Equal(t, 2, n)
+	require.Equal(t, 1, client.User.Query().CountX(ctx))
+}
+
+func TestUnloggedTables(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("SHOW server_version_num")).
+		WillReturnRows(sqlmock.NewRows([]string{"server_version_num"}).AddRow("120000"))
+	for range []string{"users", "pets", "users_unlogged", "pets_unlogged"} {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM "information_schema"."tables" WHERE "table_schema" = CURRENT_SCHEMA() AND "table_name" = $1`)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM "information_schema"."table_constraints" WHERE "table_schema" = CURRENT_SCHEMA() AND "constraint_type" = $1 AND "constraint_name" = $2`)).
+		WithArgs("FOREIGN KEY", "pets_users_pets").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.Postgres, db)))
+	var b strings.Builder
+	err = client.Schema.WriteTo(context.Background(), &b, schema.WithAtlas(false))
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	ddl := b.String()
+	require.Contains(t, ddl, `CREATE TABLE IF NOT EXISTS "users_unlogged"`)
+	require.Contains(t, ddl, `CREATE TABLE IF NOT EXISTS "pets_unlogged"`)
+	require.Contains(t, ddl, `ALTER TABLE "users_unlogged" SET UNLOGGED;`)
+	require.Contains(t, ddl, `ALTER TABLE "pets_unlogged" SET UNLOGGED;`)
+	require.NotContains(t, ddl, `ALTER TABLE "users" SET UNLOGGED`)
+	require.NotContains(t, ddl, `ALTER TABLE "pets" SET UNLOGGED`)
+	require.Equal(t, 1, strings.Count(ddl, "FOREIGN KEY"), "foreign-keys are not copied to unlogged tables")
+}
@@ -0,0 +1,35 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("email").
+			Unique(),
+		field.String("name"),
+		field.String("slug").
+			AutoSlug("name"),
+		field.String("nickname").
+			Optional(),
+		field.Int("age").
+			Optional().
+			Nillable(),
+		field.String("role").
+			Default("user"),
+		field.Strings("tags").
+			Optional(),
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package upsertall
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/entc/integration/upsertall/ent"
+	"entgo.io/ent/entc/integration/upsertall/ent/enttest"
+	"entgo.io/ent/entc/integration/upsertall/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertAll(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	require.NoError(t, client.User.UpsertAll(ctx, nil, []string{user.FieldEmail}))
+	require.Error(t, client.User.UpsertAll(ctx, []*ent.User{{Email: "a8m@example.com", Name: "Ariel"}}, nil))
+
+	age := 30
+	err := client.User.UpsertAll(ctx, []*ent.User{
+		{Email: "a8m@example.com", Name: "Ariel Mashraki", Age: &age, Tags: []string{"ent"}},
+		{Email: "rotem@example.com", Name: "Rotem", Nickname: "rtm", Role: "admin", Slug: "rotem-t"},
+	}, []string{user.FieldEmail})
+	require.NoError(t, err)
+	a8m := client.User.Query().Where(user.Email("a8m@example.com")).OnlyX(ctx)
+	// Zero values of generated and default fields were not sent.
+	require.Equal(t, "ariel-mashraki", a8m.Slug)
+	require.Equal(t, "user", a8m.Role)
+	require.Empty(t, a8m.Nickname)
+	require.Equal(t, 30, *a8m.Age)
+	require.Equal(t, []string{"ent"}, a8m.Tags)
+	rotem := client.User.Query().Where(user.Email("rotem@example.com")).OnlyX(ctx)
+	require.Equal(t, "rotem-t", rotem.Slug)
+	require.Equal(t, "admin", rotem.Role)
+	require.Equal(t, "rtm", rotem.Nickname)
+	require.Nil(t, rotem.Age)
+
+	// Conflicting rows are updated with the new values.
+	err = client.User.UpsertAll(ctx, []*ent.User{
+		{Email: "rotem@example.com", Name: "Rotem Tamir", Slug: rotem.Slug, Role: "admin"},
+		{Email: "nati@example.com", Name: "Nati"},
+	}, []string{user.FieldEmail})
+	require.NoError(t, err)
+	require.Equal(t, 3, client.User.Query().CountX(ctx))
+	rotem = client.User.GetX(ctx, rotem.ID)
+	require.Equal(t, "Rotem Tamir", rotem.Name)
+	require.Equal(t, "rotem-t", rotem.Slug)
+	require.Equal(t, "admin", rotem.Role)
+	nati := client.User.Query().Where(user.Email("nati@example.com")).OnlyX(ctx)
+	require.Equal(t, "nati", nati.Slug)
+	require.Equal(t, "user", nati.Role)
+}
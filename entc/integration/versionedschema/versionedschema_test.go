@@ -0,0 +1,80 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package versionedschema
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+	"entgo.io/ent/entc/integration/versionedschema/ent"
+	"entgo.io/ent/entc/integration/versionedschema/ent/enttest"
+	"entgo.io/ent/entc/integration/versionedschema/ent/migrate"
+
+	atlas "ariga.io/atlas/sql/migrate"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, dialect.SQLite, "file:create?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	require.NoError(t, client.Schema.VerifyVersion(ctx))
+	// Re-running the migration keeps a single version row.
+	require.NoError(t, client.Schema.Create(ctx))
+	require.NoError(t, client.Schema.VerifyVersion(ctx))
+}
+
+func TestWriteTo(t *testing.T) {
+	ctx := context.Background()
+	drv, err := sql.Open(dialect.SQLite, "file:writeto?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	defer drv.Close()
+	var b bytes.Buffer
+	client := ent.NewClient(ent.Driver(drv))
+	require.NoError(t, client.Schema.WriteTo(ctx, &b))
+	require.Contains(t, b.String(), "CREATE TABLE `schema_version`")
+	require.Contains(t, b.String(), "INSERT INTO schema_version (version) VALUES ('"+migrate.SchemaVersion+"');")
+	// The statements were written and not executed.
+	require.Error(t, client.Schema.VerifyVersion(ctx))
+}
+
+func TestDiff(t *testing.T) {
+	ctx := context.Background()
+	drv, err := sql.Open(dialect.SQLite, "file:diff?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	defer drv.Close()
+	path := t.TempDir()
+	dir, err := atlas.NewLocalDir(path)
+	require.NoError(t, err)
+	client := ent.NewClient(ent.Driver(drv))
+	require.NoError(t, client.Schema.Diff(ctx, schema.WithDir(dir)))
+	files, err := filepath.Glob(filepath.Join(path, "*.up.sql"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	up, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.Contains(t, string(up), "CREATE TABLE `schema_version`")
+	require.Contains(t, string(up), "INSERT INTO schema_version (version) VALUES ('"+migrate.SchemaVersion+"');")
+
+	// Applying the migration file records the schema version.
+	require.Error(t, client.Schema.VerifyVersion(ctx))
+	_, err = drv.DB().ExecContext(ctx, string(up))
+	require.NoError(t, err)
+	require.NoError(t, client.Schema.VerifyVersion(ctx))
+
+	_, err = drv.DB().ExecContext(ctx, "DELETE FROM schema_version")
+	require.NoError(t, err)
+	var e *ent.IncompatibleSchemaError
+	require.True(t, errors.As(client.Schema.VerifyVersion(ctx), &e))
+	require.Empty(t, e.Actual)
+}
@@ -0,0 +1,36 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Status of a user. The zero value is a valid status,
+// and NULL columns are represented by StatusUnknown.
+type Status int8
+
+const (
+	StatusInactive Status = iota
+	StatusActive
+	StatusUnknown Status = -1
+)
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+		field.Int8("status").
+			GoType(Status(0)).
+			GoZeroValue(StatusUnknown).
+			Optional(),
+	}
+}
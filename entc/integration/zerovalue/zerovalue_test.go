@@ -0,0 +1,49 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package zerovalue
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/entc/integration/zerovalue/ent"
+	"entgo.io/ent/entc/integration/zerovalue/ent/enttest"
+	"entgo.io/ent/entc/integration/zerovalue/ent/schema"
+	"entgo.io/ent/entc/integration/zerovalue/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeroValue(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", "file:zerovalue?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	a8m := client.User.Create().SetName("a8m").SetStatus(schema.StatusInactive).SaveX(ctx)
+	nati := client.User.Create().SetName("nati").SaveX(ctx)
+	alex := client.User.Create().SetName("alex").SetStatus(schema.StatusUnknown).SaveX(ctx)
+	require.Equal(t, schema.StatusInactive, a8m.Status)
+	require.Equal(t, schema.StatusUnknown, nati.Status)
+	require.Equal(t, schema.StatusUnknown, alex.Status)
+
+	// NULL columns are read as the zero value, and the zero value is stored as NULL.
+	require.Equal(t, schema.StatusUnknown, client.User.GetX(ctx, nati.ID).Status)
+	require.Equal(t, 2, client.User.Query().Where(user.StatusIsNil()).CountX(ctx))
+	a8m = a8m.Update().SetStatus(schema.StatusUnknown).SaveX(ctx)
+	require.Equal(t, schema.StatusUnknown, a8m.Status)
+	require.Equal(t, 3, client.User.Query().Where(user.StatusIsNil()).CountX(ctx))
+	a8m = a8m.Update().SetStatus(schema.StatusActive).SaveX(ctx)
+
+	// Comparing to the zero value is translated to NULL checks.
+	names := client.User.Query().Where(user.StatusEQ(schema.StatusUnknown)).Order(ent.Asc(user.FieldName)).Select(user.FieldName).StringsX(ctx)
+	require.Equal(t, []string{"alex", "nati"}, names)
+	names = client.User.Query().Where(user.Status(schema.StatusUnknown)).Order(ent.Asc(user.FieldName)).Select(user.FieldName).StringsX(ctx)
+	require.Equal(t, []string{"alex", "nati"}, names)
+	names = client.User.Query().Where(user.StatusNEQ(schema.StatusUnknown)).Select(user.FieldName).StringsX(ctx)
+	require.Equal(t, []string{"a8m"}, names)
+	require.Equal(t, a8m.ID, client.User.Query().Where(user.StatusEQ(schema.StatusActive)).OnlyIDX(ctx))
+	require.False(t, client.User.Query().Where(user.StatusEQ(schema.StatusInactive)).ExistX(ctx))
+}
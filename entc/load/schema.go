@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"entgo.io/ent"
 	"entgo.io/ent/schema"
@@ -37,26 +38,41 @@ type Position struct {
 
 // Field represents an ent.Field that was loaded from a complied user package.
 type Field struct {
-	Name          string                  `json:"name,omitempty"`
-	Info          *field.TypeInfo         `json:"type,omitempty"`
-	Tag           string                  `json:"tag,omitempty"`
-	Size          *int64                  `json:"size,omitempty"`
-	Enums         []struct{ N, V string } `json:"enums,omitempty"`
-	Unique        bool                    `json:"unique,omitempty"`
-	Nillable      bool                    `json:"nillable,omitempty"`
-	Optional      bool                    `json:"optional,omitempty"`
-	Default       bool                    `json:"default,omitempty"`
-	DefaultValue  interface{}             `json:"default_value,omitempty"`
-	DefaultKind   reflect.Kind            `json:"default_kind,omitempty"`
-	UpdateDefault bool                    `json:"update_default,omitempty"`
-	Immutable     bool                    `json:"immutable,omitempty"`
-	Validators    int                     `json:"validators,omitempty"`
-	StorageKey    string                  `json:"storage_key,omitempty"`
-	Position      *Position               `json:"position,omitempty"`
-	Sensitive     bool                    `json:"sensitive,omitempty"`
-	SchemaType    map[string]string       `json:"schema_type,omitempty"`
-	Annotations   map[string]interface{}  `json:"annotations,omitempty"`
-	Comment       string                  `json:"comment,omitempty"`
+	Name              string                  `json:"name,omitempty"`
+	Info              *field.TypeInfo         `json:"type,omitempty"`
+	Tag               string                  `json:"tag,omitempty"`
+	Size              *int64                  `json:"size,omitempty"`
+	Enums             []struct{ N, V string } `json:"enums,omitempty"`
+	Unique            bool                    `json:"unique,omitempty"`
+	Nillable          bool                    `json:"nillable,omitempty"`
+	Optional          bool                    `json:"optional,omitempty"`
+	Default           bool                    `json:"default,omitempty"`
+	DefaultValue      interface{}             `json:"default_value,omitempty"`
+	DefaultKind       reflect.Kind            `json:"default_kind,omitempty"`
+	UpdateDefault     bool                    `json:"update_default,omitempty"`
+	Immutable         bool                    `json:"immutable,omitempty"`
+	Validators        int                     `json:"validators,omitempty"`
+	ContextValidators int                     `json:"context_validators,omitempty"`
+	StorageKey        string                  `json:"storage_key,omitempty"`
+	Position          *Position               `json:"position,omitempty"`
+	Sensitive         bool                    `json:"sensitive,omitempty"`
+	SchemaType        map[string]string       `json:"schema_type,omitempty"`
+	Annotations       map[string]interface{}  `json:"annotations,omitempty"`
+	Comment           string                  `json:"comment,omitempty"`
+	DisplayName       string                  `json:"display_name,omitempty"`
+	MaxWords          int                     `json:"max_words,omitempty"`
+	SensitiveMask     int                     `json:"sensitive_mask,omitempty"`
+	AutoSlug          string                  `json:"auto_slug,omitempty"`
+	AuditBy           string                  `json:"audit_by,omitempty"`
+	Phone             bool                    `json:"phone,omitempty"`
+	URL               bool                    `json:"url,omitempty"`
+	Array             bool                    `json:"array,omitempty"`
+	Counter           bool                    `json:"counter,omitempty"`
+	HumanReadable     bool                    `json:"human_readable,omitempty"`
+	Locale            string                  `json:"locale,omitempty"`
+	Encrypted         bool                    `json:"encrypted,omitempty"`
+	Compressed        bool                    `json:"compressed,omitempty"`
+	GoZeroValue       bool                    `json:"go_zero_value,omitempty"`
 }
 
 // Edge represents an ent.Edge that was loaded from a complied user package.
@@ -74,6 +90,14 @@ type Edge struct {
 	StorageKey  *edge.StorageKey       `json:"storage_key,omitempty"`
 	Annotations map[string]interface{} `json:"annotations,omitempty"`
 	Comment     string                 `json:"comment,omitempty"`
+	Backfill    *Position              `json:"backfill,omitempty"`
+	OrderBy     *edge.Order            `json:"order_by,omitempty"`
+	Preload     edge.PreloadMode       `json:"preload,omitempty"`
+	MapToField  string                 `json:"map_to_field,omitempty"`
+	JoinFilter  *Position              `json:"join_filter,omitempty"`
+	Polymorphic []string               `json:"polymorphic,omitempty"`
+	Context     *Position              `json:"context,omitempty"`
+	Cascade     edge.CascadeMode       `json:"cascade,omitempty"`
 }
 
 // Index represents an ent.Index that was loaded from a complied user package.
@@ -82,6 +106,8 @@ type Index struct {
 	Edges       []string               `json:"edges,omitempty"`
 	Fields      []string               `json:"fields,omitempty"`
 	StorageKey  string                 `json:"storage_key,omitempty"`
+	Expr        string                 `json:"expr,omitempty"`
+	Concurrent  bool                   `json:"concurrent,omitempty"`
 	Annotations map[string]interface{} `json:"annotations,omitempty"`
 }
 
@@ -99,6 +125,11 @@ func NewEdge(ed *edge.Descriptor) *Edge {
 		Through:     ed.Through,
 		StorageKey:  ed.StorageKey,
 		Comment:     ed.Comment,
+		OrderBy:     ed.OrderBy,
+		Preload:     ed.Preload,
+		MapToField:  ed.MapToField,
+		Polymorphic: ed.Polymorphic,
+		Cascade:     ed.Cascade,
 		Annotations: make(map[string]interface{}),
 	}
 	for _, at := range ed.Annotations {
@@ -117,22 +148,37 @@ func NewField(fd *field.Descriptor) (*Field, error) {
 		return nil, fmt.Errorf("field %q: %v", fd.Name, fd.Err)
 	}
 	sf := &Field{
-		Name:          fd.Name,
-		Info:          fd.Info,
-		Tag:           fd.Tag,
-		Enums:         fd.Enums,
-		Unique:        fd.Unique,
-		Nillable:      fd.Nillable,
-		Optional:      fd.Optional,
-		Default:       fd.Default != nil,
-		UpdateDefault: fd.UpdateDefault != nil,
-		Immutable:     fd.Immutable,
-		StorageKey:    fd.StorageKey,
-		Validators:    len(fd.Validators),
-		Sensitive:     fd.Sensitive,
-		SchemaType:    fd.SchemaType,
-		Annotations:   make(map[string]interface{}),
-		Comment:       fd.Comment,
+		Name:              fd.Name,
+		Info:              fd.Info,
+		Tag:               fd.Tag,
+		Enums:             fd.Enums,
+		Unique:            fd.Unique,
+		Nillable:          fd.Nillable,
+		Optional:          fd.Optional,
+		Default:           fd.Default != nil,
+		UpdateDefault:     fd.UpdateDefault != nil,
+		Immutable:         fd.Immutable,
+		StorageKey:        fd.StorageKey,
+		Validators:        len(fd.Validators),
+		ContextValidators: len(fd.ContextValidators),
+		Sensitive:         fd.Sensitive,
+		SchemaType:        fd.SchemaType,
+		Annotations:       make(map[string]interface{}),
+		Comment:           fd.Comment,
+		DisplayName:       fd.DisplayName,
+		MaxWords:          fd.MaxWords,
+		SensitiveMask:     fd.SensitiveMask,
+		AutoSlug:          fd.AutoSlug,
+		AuditBy:           fd.AuditBy,
+		Phone:             fd.Phone,
+		URL:               fd.URL,
+		Array:             fd.Array,
+		Counter:           fd.Counter,
+		HumanReadable:     fd.HumanReadable,
+		Locale:            fd.Locale,
+		Encrypted:         fd.Keys != nil,
+		Compressed:        fd.Compression != nil,
+		GoZeroValue:       fd.ZeroValue != nil,
 	}
 	for _, at := range fd.Annotations {
 		sf.addAnnotation(at)
@@ -161,6 +207,8 @@ func NewIndex(idx *index.Descriptor) *Index {
 		Fields:      idx.Fields,
 		Unique:      idx.Unique,
 		StorageKey:  idx.StorageKey,
+		Expr:        idx.Expr,
+		Concurrent:  idx.Concurrent,
 		Annotations: make(map[string]interface{}),
 	}
 	for _, at := range idx.Annotations {
@@ -191,8 +239,25 @@ func MarshalSchema(schema ent.Interface) (b []byte, err error) {
 	if err != nil {
 		return nil, fmt.Errorf("schema %q: %w", s.Name, err)
 	}
-	for _, e := range edges {
-		s.Edges = append(s.Edges, NewEdge(e.Descriptor()))
+	for i, e := range edges {
+		ed := e.Descriptor()
+		ne := NewEdge(ed)
+		if ed.Backfill != nil {
+			if err := checkBackfill(ed); err != nil {
+				return nil, fmt.Errorf("schema %q: %w", s.Name, err)
+			}
+			ne.Backfill = &Position{Index: i}
+		}
+		if ed.JoinFilter != nil {
+			if err := checkJoinFilter(ed); err != nil {
+				return nil, fmt.Errorf("schema %q: %w", s.Name, err)
+			}
+			ne.JoinFilter = &Position{Index: i}
+		}
+		if ed.Context != nil {
+			ne.Context = &Position{Index: i}
+		}
+		s.Edges = append(s.Edges, ne)
 	}
 	indexes, err := safeIndexes(schema)
 	if err != nil {
@@ -253,6 +318,15 @@ func (s *Schema) loadMixin(schema ent.Interface) error {
 			return fmt.Errorf("mixin %q: %w", name, err)
 		}
 		for _, e := range edges {
+			if e.Descriptor().Backfill != nil {
+				return fmt.Errorf("mixin %q: backfill of edge %q is not supported in mixins", name, e.Descriptor().Name)
+			}
+			if e.Descriptor().JoinFilter != nil {
+				return fmt.Errorf("mixin %q: join filter of edge %q is not supported in mixins", name, e.Descriptor().Name)
+			}
+			if e.Descriptor().Context != nil {
+				return fmt.Errorf("mixin %q: context of edge %q is not supported in mixins", name, e.Descriptor().Name)
+			}
 			s.Edges = append(s.Edges, NewEdge(e.Descriptor()))
 		}
 		indexes, err := safeIndexes(mx)
@@ -449,6 +523,32 @@ func safePolicy(schema interface{ Policy() ent.Policy }) (policy ent.Policy, err
 	return schema.Policy(), nil
 }
 
+// checkBackfill checks that the backfill function of the edge is of type func(*ent.Tx) error.
+func checkBackfill(ed *edge.Descriptor) error {
+	t := reflect.TypeOf(ed.Backfill)
+	if t.Kind() != reflect.Func || t.NumIn() != 1 || t.NumOut() != 1 ||
+		t.In(0).Kind() != reflect.Ptr || t.In(0).Elem().Name() != "Tx" || t.Out(0) != reflect.TypeOf((*error)(nil)).Elem() {
+		return fmt.Errorf("edge %q: expect type (func(*ent.Tx) error) for backfill function, got %s", ed.Name, t)
+	}
+	return nil
+}
+
+// checkJoinFilter checks that the join filter of the edge is of type
+// func(*ent.TQuery) *ent.TQuery, where T is the edge schema.
+func checkJoinFilter(ed *edge.Descriptor) error {
+	query := "TQuery"
+	if ed.Through != nil {
+		query = ed.Through.T + "Query"
+	}
+	t := reflect.TypeOf(ed.JoinFilter)
+	if t.Kind() != reflect.Func || t.NumIn() != 1 || t.NumOut() != 1 || t.In(0) != t.Out(0) ||
+		t.In(0).Kind() != reflect.Ptr || !strings.HasSuffix(t.In(0).Elem().Name(), "Query") ||
+		ed.Through != nil && t.In(0).Elem().Name() != query {
+		return fmt.Errorf("edge %q: expect type (func(*ent.%[2]s) *ent.%[2]s) for join filter, got %s", ed.Name, query, t)
+	}
+	return nil
+}
+
 func indirect(t reflect.Type) reflect.Type {
 	for t.Kind() == reflect.Ptr {
 		t = t.Elem()
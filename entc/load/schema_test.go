@@ -277,6 +277,133 @@ func TestMarshalFails(t *testing.T) {
 	require.EqualError(t, err, `schema "InvalidUUID": field "invalid": expect type (func() uuid.UUID) for uuid default value`)
 }
 
+// Tx and UserQuery mock the generated types.
+type (
+	Tx        struct{}
+	UserQuery struct{}
+)
+
+type WithBackfill struct {
+	ent.Schema
+}
+
+func (WithBackfill) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("users", User.Type),
+		edge.To("owner", User.Type).
+			Unique().
+			Backfill(func(*Tx) error { return nil }),
+	}
+}
+
+type InvalidBackfill struct {
+	ent.Schema
+}
+
+func (InvalidBackfill) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("owner", User.Type).
+			Unique().
+			Backfill(func() error { return nil }),
+	}
+}
+
+type BackfillMixin struct {
+	mixin.Schema
+}
+
+func (BackfillMixin) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("owner", User.Type).
+			Backfill(func() error { return nil }),
+	}
+}
+
+type WithBackfillMixin struct {
+	ent.Schema
+}
+
+func (WithBackfillMixin) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		BackfillMixin{},
+	}
+}
+
+func TestMarshalBackfill(t *testing.T) {
+	buf, err := MarshalSchema(WithBackfill{})
+	require.NoError(t, err)
+	schema, err := UnmarshalSchema(buf)
+	require.NoError(t, err)
+	require.Nil(t, schema.Edges[0].Backfill)
+	require.Equal(t, &Position{Index: 1}, schema.Edges[1].Backfill)
+
+	buf, err = MarshalSchema(InvalidBackfill{})
+	require.Nil(t, buf)
+	require.EqualError(t, err, `schema "InvalidBackfill": edge "owner": expect type (func(*ent.Tx) error) for backfill function, got func() error`)
+
+	buf, err = MarshalSchema(WithBackfillMixin{})
+	require.Nil(t, buf)
+	require.EqualError(t, err, `schema "WithBackfillMixin": mixin "BackfillMixin": backfill of edge "owner" is not supported in mixins`)
+}
+
+type WithJoinFilter struct {
+	ent.Schema
+}
+
+func (WithJoinFilter) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("users", User.Type),
+		edge.To("active_users", User.Type).
+			JoinFilter(func(q *UserQuery) *UserQuery { return q }),
+	}
+}
+
+type InvalidJoinFilter struct {
+	ent.Schema
+}
+
+func (InvalidJoinFilter) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("active_users", User.Type).
+			Through("memberships", Group.Type).
+			JoinFilter(func(q *UserQuery) *UserQuery { return q }),
+	}
+}
+
+func TestMarshalJoinFilter(t *testing.T) {
+	buf, err := MarshalSchema(WithJoinFilter{})
+	require.NoError(t, err)
+	schema, err := UnmarshalSchema(buf)
+	require.NoError(t, err)
+	require.Nil(t, schema.Edges[0].JoinFilter)
+	require.Equal(t, &Position{Index: 1}, schema.Edges[1].JoinFilter)
+
+	buf, err = MarshalSchema(InvalidJoinFilter{})
+	require.Nil(t, buf)
+	require.EqualError(t, err, `schema "InvalidJoinFilter": edge "active_users": expect type (func(*ent.GroupQuery) *ent.GroupQuery) for join filter, got func(*load.UserQuery) *load.UserQuery`)
+}
+
+type WithEdgeContext struct {
+	ent.Schema
+}
+
+func (WithEdgeContext) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("users", User.Type),
+		edge.To("detached_users", User.Type).
+			WithContext(func(ctx context.Context) context.Context { return ctx }),
+	}
+}
+
+func TestMarshalEdgeContext(t *testing.T) {
+	buf, err := MarshalSchema(WithEdgeContext{})
+	require.NoError(t, err)
+	schema, err := UnmarshalSchema(buf)
+	require.NoError(t, err)
+	require.Nil(t, schema.Edges[0].Context)
+	require.Equal(t, &Position{Index: 1}, schema.Edges[1].Context)
+}
+
 type WithDefaults struct {
 	ent.Schema
 }
@@ -515,3 +642,35 @@ func TestMarshalMixin(t *testing.T) {
 		require.False(t, schema.Policy[1].MixedIn)
 	})
 }
+
+type TaggableMixin struct {
+	mixin.Schema
+}
+
+func (TaggableMixin) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("tags", Group.Type).
+			Through("tweet_tags", User.Type),
+	}
+}
+
+type WithTaggableMixin struct {
+	ent.Schema
+}
+
+func (WithTaggableMixin) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TaggableMixin{},
+	}
+}
+
+func TestMarshalMixinThrough(t *testing.T) {
+	buf, err := MarshalSchema(WithTaggableMixin{})
+	require.NoError(t, err)
+	schema := &Schema{}
+	require.NoError(t, json.Unmarshal(buf, schema))
+	require.Len(t, schema.Edges, 1)
+	require.Equal(t, "tags", schema.Edges[0].Name)
+	require.Equal(t, "Group", schema.Edges[0].Type)
+	require.Equal(t, &struct{ N, T string }{N: "tweet_tags", T: "User"}, schema.Edges[0].Through)
+}
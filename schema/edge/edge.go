@@ -5,6 +5,7 @@
 package edge
 
 import (
+	"context"
 	"reflect"
 
 	"entgo.io/ent/schema"
@@ -12,28 +13,65 @@ import (
 
 // A Descriptor for edge configuration.
 type Descriptor struct {
-	Tag         string                 // struct tag.
-	Type        string                 // edge type.
-	Name        string                 // edge name.
-	Field       string                 // edge field name (e.g. foreign-key).
-	RefName     string                 // ref name; inverse only.
-	Ref         *Descriptor            // edge reference; to/from of the same type.
-	Through     *struct{ N, T string } // through type and name.
-	Unique      bool                   // unique edge.
-	Inverse     bool                   // inverse edge.
-	Required    bool                   // required on creation.
-	StorageKey  *StorageKey            // optional storage-key configuration.
-	Annotations []schema.Annotation    // edge annotations.
-	Comment     string                 // edge comment.
+	Tag         string                                // struct tag.
+	Type        string                                // edge type.
+	Name        string                                // edge name.
+	Field       string                                // edge field name (e.g. foreign-key).
+	RefName     string                                // ref name; inverse only.
+	Ref         *Descriptor                           // edge reference; to/from of the same type.
+	Through     *struct{ N, T string }                // through type and name.
+	Unique      bool                                  // unique edge.
+	Inverse     bool                                  // inverse edge.
+	Required    bool                                  // required on creation.
+	StorageKey  *StorageKey                           // optional storage-key configuration.
+	Annotations []schema.Annotation                   // edge annotations.
+	Comment     string                                // edge comment.
+	Backfill    interface{}                           // backfill function.
+	OrderBy     *Order                                // default order of edge queries.
+	Preload     PreloadMode                           // preload mode of the edge.
+	MapToField  string                                // getter name of the foreign-key.
+	JoinFilter  interface{}                           // join table filter.
+	Polymorphic []string                              // polymorphic edge types.
+	Context     func(context.Context) context.Context // context of edge queries.
+	Cascade     CascadeMode                           // cascade mode of deletions.
 }
 
+// Order holds the default order configuration of an edge.
+type Order struct {
+	Field     string // field name.
+	Direction string // ASC or DESC.
+}
+
+// PreloadMode defines how a unique edge is preloaded with its parent entities.
+type PreloadMode string
+
+// InlineJSON preloads the edge using a correlated sub-query that returns the
+// neighbor as a JSON column of the parent query, instead of a second query.
+const InlineJSON PreloadMode = "inline_json"
+
+// CascadeMode defines how deletions of an entity are propagated to its edges.
+type CascadeMode string
+
+// SoftDelete propagates soft-deletes of an entity to the entities of its edge, by
+// marking them as deleted in the same transaction. Both types of the edge must be
+// defined with the mixin.SoftDelete mixin.
+const SoftDelete CascadeMode = "soft_delete"
+
 // To defines an association edge between two vertices.
 func To(name string, t interface{}) *assocBuilder {
 	return &assocBuilder{desc: &Descriptor{Name: name, Type: typ(t)}}
 }
 
 // From represents a reversed-edge between two vertices that has a back-reference to its source edge.
+// If the given type is created by schema.Polymorphic, the edge is a polymorphic edge that can point
+// to entities of multiple types, and it does not have a back-reference.
+//
+//	edge.From("commentable", schema.Polymorphic(Post.Type, Video.Type))
+//
 func From(name string, t interface{}) *inverseBuilder {
+	if types, ok := t.(schema.PolymorphicTypes); ok {
+		return &inverseBuilder{desc: &Descriptor{Name: name, Inverse: true, Polymorphic: types}}
+	}
 	return &inverseBuilder{desc: &Descriptor{Name: name, Type: typ(t), Inverse: true}}
 }
 
@@ -119,6 +157,100 @@ func (b *assocBuilder) StorageKey(opts ...StorageOption) *assocBuilder {
 	return b
 }
 
+// Backfill sets a function for populating the foreign-key (or the join table) of the edge
+// when it is added to an existing schema. The function is expected to be of type
+// func(*ent.Tx) error, and it is executed by the migration engine after the schema
+// changes were applied, in the same transaction.
+//
+//	edge.To("category", Category.Type).
+//		Unique().
+//		Backfill(func(tx *ent.Tx) error {
+//			return backfillCategories(tx)
+//		})
+//
+func (b *assocBuilder) Backfill(fn interface{}) *assocBuilder {
+	b.desc.Backfill = fn
+	return b
+}
+
+// JoinFilter sets a filter that is applied on the join table (edge schema) of M2M edges
+// that were defined with Through, in addition to the foreign-key equality. The function
+// is expected to be of type func(*ent.TQuery) *ent.TQuery, where T is the edge schema,
+// and its predicates are applied on traversals, edge predicates and eager-loading.
+//
+//	edge.To("active_members", Member.Type).
+//		Through("memberships", Membership.Type).
+//		JoinFilter(func(q *ent.MembershipQuery) *ent.MembershipQuery {
+//			return q.Where(membership.StatusEQ("active"))
+//		})
+//
+func (b *assocBuilder) JoinFilter(fn interface{}) *assocBuilder {
+	b.desc.JoinFilter = fn
+	return b
+}
+
+// WithContext sets a function that derives the context of the edge queries (e.g. QueryPets
+// and eager-loading) from the context they are executed with. For example, it can be used
+// for detaching edge traversals that run in background goroutines from the request cancellation.
+//
+//	edge.To("pets", Pet.Type).
+//		WithContext(func(ctx context.Context) context.Context {
+//			return context.WithoutCancel(ctx)
+//		})
+//
+func (b *assocBuilder) WithContext(fn func(context.Context) context.Context) *assocBuilder {
+	b.desc.Context = fn
+	return b
+}
+
+// OrderBy sets the default order of the edge queries (e.g. QueryPets) and eager-loading
+// by the given field of the edge type, and the given direction (ASC or DESC). The default
+// order is replaced by explicit calls to the Order method of the query builder.
+//
+//	edge.To("pets", Pet.Type).
+//		OrderBy("name", "ASC")
+//
+func (b *assocBuilder) OrderBy(field, direction string) *assocBuilder {
+	b.desc.OrderBy = &Order{Field: field, Direction: direction}
+	return b
+}
+
+// Preload configures the edge to be loaded with its parent entities on every
+// query using the given mode. Only unique edges can be preloaded.
+//
+//	edge.To("address", Address.Type).
+//		Unique().
+//		Preload(edge.InlineJSON)
+//
+func (b *assocBuilder) Preload(mode PreloadMode) *assocBuilder {
+	b.desc.Preload = mode
+	return b
+}
+
+// Cascade configures the edge to propagate the deletions of its parent entities
+// to the entities of the edge using the given mode.
+//
+//	edge.To("posts", Post.Type).
+//		Cascade(edge.SoftDelete)
+//
+func (b *assocBuilder) Cascade(mode CascadeMode) *assocBuilder {
+	b.desc.Cascade = mode
+	return b
+}
+
+// MapToField exposes the foreign-key value of a unique edge using a getter
+// method on the entity, named after the given field name. It allows reading
+// the neighbor identifier without querying the edge.
+//
+//	edge.To("owner", User.Type).
+//		Unique().
+//		MapToField("owner_id")
+//
+func (b *assocBuilder) MapToField(name string) *assocBuilder {
+	b.desc.MapToField = name
+	return b
+}
+
 // Annotations adds a list of annotations to the edge object to be used by
 // codegen extensions.
 //
@@ -198,6 +330,95 @@ func (b *inverseBuilder) Through(name string, t interface{}) *inverseBuilder {
 	return b
 }
 
+// Backfill sets a function for populating the foreign-key (or the join table) of the edge
+// when it is added to an existing schema. The function is expected to be of type
+// func(*ent.Tx) error, and it is executed by the migration engine after the schema
+// changes were applied, in the same transaction.
+//
+//	edge.From("owner", User.Type).
+//		Ref("pets").
+//		Unique().
+//		Backfill(func(tx *ent.Tx) error {
+//			return backfillOwners(tx)
+//		})
+//
+func (b *inverseBuilder) Backfill(fn interface{}) *inverseBuilder {
+	b.desc.Backfill = fn
+	return b
+}
+
+// JoinFilter sets a filter that is applied on the join table (edge schema) of M2M edges
+// that were defined with Through, in addition to the foreign-key equality. The function
+// is expected to be of type func(*ent.TQuery) *ent.TQuery, where T is the edge schema,
+// and its predicates are applied on traversals, edge predicates and eager-loading.
+//
+//	edge.From("active_groups", Group.Type).
+//		Ref("members").
+//		Through("memberships", Membership.Type).
+//		JoinFilter(func(q *ent.MembershipQuery) *ent.MembershipQuery {
+//			return q.Where(membership.StatusEQ("active"))
+//		})
+//
+func (b *inverseBuilder) JoinFilter(fn interface{}) *inverseBuilder {
+	b.desc.JoinFilter = fn
+	return b
+}
+
+// WithContext sets a function that derives the context of the edge queries (e.g. QueryPets
+// and eager-loading) from the context they are executed with. For example, it can be used
+// for detaching edge traversals that run in background goroutines from the request cancellation.
+//
+//	edge.From("owner", User.Type).
+//		Ref("pets").
+//		WithContext(func(ctx context.Context) context.Context {
+//			return context.WithoutCancel(ctx)
+//		})
+//
+func (b *inverseBuilder) WithContext(fn func(context.Context) context.Context) *inverseBuilder {
+	b.desc.Context = fn
+	return b
+}
+
+// OrderBy sets the default order of the edge queries (e.g. QueryPets) and eager-loading
+// by the given field of the edge type, and the given direction (ASC or DESC). The default
+// order is replaced by explicit calls to the Order method of the query builder.
+//
+//	edge.From("followers", User.Type).
+//		Ref("following").
+//		OrderBy("name", "ASC")
+//
+func (b *inverseBuilder) OrderBy(field, direction string) *inverseBuilder {
+	b.desc.OrderBy = &Order{Field: field, Direction: direction}
+	return b
+}
+
+// Preload configures the edge to be loaded with its parent entities on every
+// query using the given mode. Only unique edges can be preloaded.
+//
+//	edge.From("owner", User.Type).
+//		Ref("pets").
+//		Unique().
+//		Preload(edge.InlineJSON)
+//
+func (b *inverseBuilder) Preload(mode PreloadMode) *inverseBuilder {
+	b.desc.Preload = mode
+	return b
+}
+
+// MapToField exposes the foreign-key value of a unique edge using a getter
+// method on the entity, named after the given field name. It allows reading
+// the neighbor identifier without querying the edge.
+//
+//	edge.From("owner", User.Type).
+//		Ref("pets").
+//		Unique().
+//		MapToField("owner_id")
+//
+func (b *inverseBuilder) MapToField(name string) *inverseBuilder {
+	b.desc.MapToField = name
+	return b
+}
+
 // Annotations adds a list of annotations to the edge object to be used by
 // codegen extensions.
 //
@@ -97,6 +97,48 @@ func TestEdge(t *testing.T) {
 	assert.Equal("followers", from.Tag)
 	assert.Equal("following", from.Ref.Tag)
 	assert.Equal(edge.StorageKey{Table: "user_followers", Symbols: []string{"users_followers"}, Columns: []string{"following_id", "followers_id"}}, *from.Ref.StorageKey)
+
+	e = edge.To("friends", User.Type).
+		OrderBy("name", "ASC").
+		Descriptor()
+	assert.Equal(&edge.Order{Field: "name", Direction: "ASC"}, e.OrderBy)
+	from = edge.To("following", User.Type).
+		From("followers").
+		OrderBy("age", "DESC").
+		Descriptor()
+	assert.Equal(&edge.Order{Field: "age", Direction: "DESC"}, from.OrderBy)
+	assert.Nil(from.Ref.OrderBy)
+
+	e = edge.To("spouse", User.Type).
+		Unique().
+		Preload(edge.InlineJSON).
+		Descriptor()
+	assert.Equal(edge.InlineJSON, e.Preload)
+	from = edge.To("following", User.Type).
+		From("followers").
+		Unique().
+		Preload(edge.InlineJSON).
+		Descriptor()
+	assert.Equal(edge.InlineJSON, from.Preload)
+	assert.Empty(from.Ref.Preload)
+
+	e = edge.To("children", User.Type).
+		Cascade(edge.SoftDelete).
+		Descriptor()
+	assert.Equal(edge.SoftDelete, e.Cascade)
+
+	e = edge.To("owner", User.Type).
+		Unique().
+		MapToField("owner_id").
+		Descriptor()
+	assert.Equal("owner_id", e.MapToField)
+	from = edge.To("pets", User.Type).
+		From("owner").
+		Unique().
+		MapToField("owner_id").
+		Descriptor()
+	assert.Equal("owner_id", from.MapToField)
+	assert.Empty(from.Ref.MapToField)
 }
 
 type GQL struct {
@@ -107,6 +149,19 @@ func (GQL) Name() string {
 	return "GQL"
 }
 
+func TestPolymorphic(t *testing.T) {
+	type Post struct{ ent.Schema }
+	type Video struct{ ent.Schema }
+	e := edge.From("commentable", schema.Polymorphic(Post.Type, Video.Type)).
+		Required().
+		Descriptor()
+	assert.Equal(t, "commentable", e.Name)
+	assert.Empty(t, e.Type)
+	assert.True(t, e.Inverse)
+	assert.True(t, e.Required)
+	assert.Equal(t, []string{"Post", "Video"}, e.Polymorphic)
+}
+
 func TestAnnotations(t *testing.T) {
 	type User struct{ ent.Schema }
 	to := edge.To("user", User.Type).
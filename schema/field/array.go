@@ -0,0 +1,121 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent/dialect"
+)
+
+// StringArray returns a new Field with type TextArray, that is stored in a native
+// Postgres "text[]" column. Unlike Strings, that are stored as JSON, the generated
+// package has additional <Field>Contains, <Field>Length and <Field>Overlaps predicates
+// that use the Postgres array operators.
+//
+// In MySQL and SQLite, the values are stored in a "text" column using the Postgres
+// array literal format, and the array predicates are not supported.
+//
+//	field.StringArray("tags").
+//		Optional()
+//
+func StringArray(name string) *otherBuilder {
+	b := Other(name, TextArray{}).
+		SchemaType(map[string]string{
+			dialect.MySQL:    "text",
+			dialect.Postgres: "text[]",
+			dialect.SQLite:   "text",
+		})
+	b.desc.Array = true
+	return b
+}
+
+// TextArray is a list of strings that is stored in a Postgres "text[]" column.
+// It implements the driver.Valuer and sql.Scanner interfaces by encoding the
+// values in the Postgres array literal format (e.g. {"a","b"}).
+type TextArray []string
+
+// Value implements the driver.Valuer interface.
+func (a TextArray) Value() (driver.Value, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, s := range a {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('"')
+		for _, r := range s {
+			if r == '"' || r == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		}
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (a *TextArray) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("field: unexpected type %T for TextArray", src)
+	}
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return fmt.Errorf("field: invalid array literal %q", s)
+	}
+	s = s[1 : len(s)-1]
+	arr := TextArray{}
+	for len(s) > 0 {
+		var (
+			b      strings.Builder
+			quoted = s[0] == '"'
+			i      = 0
+		)
+		if quoted {
+			for i = 1; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+				if i < len(s) {
+					b.WriteByte(s[i])
+				}
+			}
+			if i >= len(s) {
+				return fmt.Errorf("field: unterminated element in array literal %q", s)
+			}
+			i++
+		} else {
+			for ; i < len(s) && s[i] != ','; i++ {
+				b.WriteByte(s[i])
+			}
+		}
+		// Unquoted NULL elements are scanned as empty strings.
+		if v := b.String(); quoted || v != "NULL" {
+			arr = append(arr, v)
+		} else {
+			arr = append(arr, "")
+		}
+		if s = s[i:]; len(s) > 0 {
+			if s[0] != ',' {
+				return fmt.Errorf("field: unexpected character %q in array literal", s[0])
+			}
+			s = s[1:]
+		}
+	}
+	*a = arr
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field_test
+
+import (
+	"testing"
+
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringArray(t *testing.T) {
+	fd := field.StringArray("tags").Optional().Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.True(t, fd.Array)
+	assert.True(t, fd.Optional)
+	assert.Equal(t, field.TypeOther, fd.Info.Type)
+	assert.Equal(t, "field.TextArray", fd.Info.Ident)
+	assert.Equal(t, map[string]string{"mysql": "text", "postgres": "text[]", "sqlite3": "text"}, fd.SchemaType)
+}
+
+func TestTextArray(t *testing.T) {
+	v, err := field.TextArray{"a", `b "c"`, `d\e`, ""}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{"a","b \"c\"","d\\e",""}`, v)
+
+	var a field.TextArray
+	require.NoError(t, a.Scan(v))
+	assert.Equal(t, field.TextArray{"a", `b "c"`, `d\e`, ""}, a)
+	require.NoError(t, a.Scan([]byte(`{a,NULL,"x,y"}`)))
+	assert.Equal(t, field.TextArray{"a", "", "x,y"}, a)
+	require.NoError(t, a.Scan("{}"))
+	assert.Equal(t, field.TextArray{}, a)
+	require.NoError(t, a.Scan(nil))
+	assert.Nil(t, a)
+
+	assert.Error(t, a.Scan("a,b"))
+	assert.Error(t, a.Scan(`{"a}`))
+	assert.Error(t, a.Scan(`{"a"b}`))
+	assert.Error(t, a.Scan(1))
+}
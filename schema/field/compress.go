@@ -0,0 +1,78 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"io"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+// Compression is a streaming codec that is used for compressing field values
+// before they are stored in the database. See the Compress option for more info.
+type Compression interface {
+	// Name returns the name of the codec. e.g. "lz4".
+	Name() string
+	// NewWriter returns a writer that compresses the data written to it
+	// into w. The data is flushed when the writer is closed.
+	NewWriter(w io.Writer) io.WriteCloser
+	// NewReader returns a reader that decompresses the data read from r.
+	NewReader(r io.Reader) io.Reader
+}
+
+// LZ4 is a Compression that uses the LZ4 frame format.
+var LZ4 Compression = lz4Codec{}
+
+type lz4Codec struct{}
+
+// Name implements the Compression interface.
+func (lz4Codec) Name() string { return "lz4" }
+
+// NewWriter implements the Compression interface.
+func (lz4Codec) NewWriter(w io.Writer) io.WriteCloser { return lz4.NewWriter(w) }
+
+// NewReader implements the Compression interface.
+func (lz4Codec) NewReader(r io.Reader) io.Reader { return lz4.NewReader(r) }
+
+// Compress compresses the given data using the given codec.
+func Compress(c Compression, data []byte) ([]byte, error) {
+	var b bytes.Buffer
+	w := c.NewWriter(&b)
+	if _, err := w.Write(data); err != nil {
+		return nil, fmt.Errorf("field: compress with %s: %w", c.Name(), err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("field: compress with %s: %w", c.Name(), err)
+	}
+	return b.Bytes(), nil
+}
+
+// Decompress decompresses the given data that was created by Compress.
+func Decompress(c Compression, data []byte) ([]byte, error) {
+	b, err := io.ReadAll(c.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		return nil, fmt.Errorf("field: decompress with %s: %w", c.Name(), err)
+	}
+	return b, nil
+}
+
+// CompressedValue returns a driver.Valuer that compresses the given data
+// with Compress when it is passed to the database driver.
+func CompressedValue(c Compression, data []byte) driver.Valuer {
+	return compressedValue{c: c, data: data}
+}
+
+type compressedValue struct {
+	c    Compression
+	data []byte
+}
+
+// Value implements the driver.Valuer interface.
+func (v compressedValue) Value() (driver.Value, error) {
+	return Compress(v.c, v.data)
+}
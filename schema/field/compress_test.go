@@ -0,0 +1,42 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field_test
+
+import (
+	"strings"
+	"testing"
+
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompress(t *testing.T) {
+	fd := field.Text("body").Compress(field.LZ4).Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, field.LZ4, fd.Compression)
+	fd = field.Bytes("doc").Compress(field.LZ4).Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, field.LZ4, fd.Compression)
+	fd = field.Text("body").Compress(nil).Descriptor()
+	assert.EqualError(t, fd.Err, `field.String("body"): Compress requires a non-nil codec`)
+
+	doc := strings.Repeat("ent is an entity framework for Go. ", 100)
+	data, err := field.Compress(field.LZ4, []byte(doc))
+	assert.NoError(t, err)
+	assert.Less(t, len(data), len(doc))
+	plain, err := field.Decompress(field.LZ4, data)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, string(plain))
+
+	v, err := field.CompressedValue(field.LZ4, []byte(doc)).Value()
+	assert.NoError(t, err)
+	plain, err = field.Decompress(field.LZ4, v.([]byte))
+	assert.NoError(t, err)
+	assert.Equal(t, doc, string(plain))
+
+	_, err = field.Decompress(field.LZ4, []byte(doc))
+	assert.Error(t, err, "uncompressed data")
+}
@@ -0,0 +1,32 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Duration returns a new int64 field with the time.Duration Go type. Durations are
+// stored in nanoseconds (e.g. BIGINT in SQL dialects).
+//
+//	field.Duration("timeout").
+//		Default(int64(30 * time.Second)).
+//		HumanReadable()
+//
+func Duration(name string) *int64Builder {
+	return Int64(name).GoType(time.Duration(0))
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// checkHumanReadable checks that the human-readable field holds
+// an int64 or a time.Duration value.
+func (d *Descriptor) checkHumanReadable() {
+	if d.Err == nil && d.Info.RType != nil && d.Info.RType.rtype != durationType {
+		d.Err = fmt.Errorf("field.Int64(%q): HumanReadable is not supported by GoType %s", d.Name, d.Info)
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field_test
+
+import (
+	"testing"
+	"time"
+
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuration(t *testing.T) {
+	fd := field.Duration("timeout").
+		Default(int64(30 * time.Second)).
+		HumanReadable().
+		Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.True(t, fd.HumanReadable)
+	assert.Equal(t, field.TypeInt64, fd.Info.Type)
+	assert.Equal(t, "time.Duration", fd.Info.Ident)
+	assert.Equal(t, "time", fd.Info.PkgPath)
+
+	fd = field.Int64("timeout").HumanReadable().Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.True(t, fd.HumanReadable)
+
+	fd = field.Int64("timeout").HumanReadable().GoType(Nanos(0)).Descriptor()
+	assert.EqualError(t, fd.Err, `field.Int64("timeout"): HumanReadable is not supported by GoType field_test.Nanos`)
+}
+
+type Nanos int64
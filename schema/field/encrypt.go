@@ -0,0 +1,128 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// KeyVersionStore provides the versioned keys of encrypted fields. Values are always
+// encrypted with the current key, and decrypted with the key they were encrypted with.
+// Hence, keys can be rotated by adding a new version and making it the current one,
+// as long as the previous versions are still available for reading.
+type KeyVersionStore interface {
+	// CurrentKey returns the version and the value of the key that is used for
+	// encrypting new values. The key must be 16, 24 or 32 bytes long.
+	CurrentKey() (id int, key []byte)
+	// Key returns the key of the given version.
+	Key(id int) ([]byte, error)
+}
+
+// Encrypt encrypts the given plaintext using AES-GCM with the current key of the given
+// store. The returned ciphertext is prefixed with the version of the key that was used
+// (e.g. "v2:<base64>").
+func Encrypt(ks KeyVersionStore, plaintext string) (string, error) {
+	id, key := ks.CurrentKey()
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", fmt.Errorf("field: encrypt with key %d: %w", id, err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("field: generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return "v" + strconv.Itoa(id) + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts the given ciphertext that was created by Encrypt, using the key
+// version that is stored in its prefix.
+func Decrypt(ks KeyVersionStore, ciphertext string) (string, error) {
+	version, data, ok := cut(ciphertext)
+	if !ok {
+		return "", errors.New("field: missing key version in ciphertext")
+	}
+	id, err := strconv.Atoi(version)
+	if err != nil {
+		return "", fmt.Errorf("field: invalid key version %q: %w", version, err)
+	}
+	key, err := ks.Key(id)
+	if err != nil {
+		return "", fmt.Errorf("field: get key %d: %w", id, err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", fmt.Errorf("field: decrypt with key %d: %w", id, err)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", fmt.Errorf("field: decode ciphertext: %w", err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("field: ciphertext is too short")
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("field: decrypt with key %d: %w", id, err)
+	}
+	return string(plaintext), nil
+}
+
+// EncryptedSize returns the maximum length of the ciphertext that is created by Encrypt
+// for values of up to n bytes. The ciphertext holds the key version prefix, and the base64
+// encoding of the nonce, the sealed value and its authentication tag.
+func EncryptedSize(n int64) int64 {
+	const (
+		prefix   = len("v:") + 20 // max length of the key version.
+		overhead = 12 + 16        // standard nonce and tag sizes of AES-GCM.
+	)
+	return int64(prefix) + (n+overhead+2)/3*4
+}
+
+// EncryptedValue returns a driver.Valuer that encrypts the given plaintext
+// with Encrypt when it is passed to the database driver.
+func EncryptedValue(ks KeyVersionStore, plaintext string) driver.Valuer {
+	return encryptedValue{ks: ks, plaintext: plaintext}
+}
+
+type encryptedValue struct {
+	ks        KeyVersionStore
+	plaintext string
+}
+
+// Value implements the driver.Valuer interface.
+func (v encryptedValue) Value() (driver.Value, error) {
+	return Encrypt(v.ks, v.plaintext)
+}
+
+// newGCM returns an AES-GCM cipher for the given key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// cut splits a "v<id>:<data>" ciphertext into its version and data parts.
+func cut(s string) (version, data string, ok bool) {
+	if !strings.HasPrefix(s, "v") {
+		return "", "", false
+	}
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return "", "", false
+	}
+	return s[1:i], s[i+1:], true
+}
@@ -0,0 +1,79 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type keys struct {
+	current int
+	keys    map[int][]byte
+}
+
+func (k *keys) CurrentKey() (int, []byte) { return k.current, k.keys[k.current] }
+
+func (k *keys) Key(id int) ([]byte, error) {
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("key %d was not found", id)
+	}
+	return key, nil
+}
+
+func TestEncryptVersioned(t *testing.T) {
+	ks := &keys{current: 1, keys: map[int][]byte{1: bytes.Repeat([]byte{1}, 32)}}
+	fd := field.String("ssn").EncryptVersioned(ks).Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, ks, fd.Keys)
+	fd = field.String("ssn").EncryptVersioned(nil).Descriptor()
+	assert.EqualError(t, fd.Err, `field.String("ssn"): EncryptVersioned requires a non-nil key store`)
+
+	v1, err := field.Encrypt(ks, "123-45-6789")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(v1, "v1:"))
+	assert.NotContains(t, v1, "6789")
+	other, err := field.Encrypt(ks, "123-45-6789")
+	assert.NoError(t, err)
+	assert.NotEqual(t, v1, other, "nonce should be random")
+
+	// Rotate the key. Old values are decrypted with their own key version.
+	ks.keys[2], ks.current = bytes.Repeat([]byte{2}, 32), 2
+	v2, err := field.Encrypt(ks, "123-45-6789")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(v2, "v2:"))
+	for _, c := range []string{v1, v2} {
+		plain, err := field.Decrypt(ks, c)
+		assert.NoError(t, err)
+		assert.Equal(t, "123-45-6789", plain)
+	}
+
+	// Retired keys cannot be used for decryption.
+	delete(ks.keys, 1)
+	_, err = field.Decrypt(ks, v1)
+	assert.EqualError(t, err, "field: get key 1: key 1 was not found")
+	_, err = field.Decrypt(ks, "plaintext")
+	assert.EqualError(t, err, "field: missing key version in ciphertext")
+
+	for _, s := range []string{"", "secret", strings.Repeat("s", 255)} {
+		c, err := field.Encrypt(ks, s)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, int64(len(c)), field.EncryptedSize(int64(len(s))))
+	}
+	assert.Equal(t, int64(402), field.EncryptedSize(255))
+
+	v, err := field.EncryptedValue(ks, "secret").Value()
+	assert.NoError(t, err)
+	plain, err := field.Decrypt(ks, v.(string))
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", plain)
+}
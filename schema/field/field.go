@@ -5,6 +5,7 @@
 package field
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"errors"
@@ -12,29 +13,51 @@ import (
 	"math"
 	"reflect"
 	"regexp"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/schema"
 )
 
 // String returns a new Field with type string.
 func String(name string) *stringBuilder {
-	return &stringBuilder{&Descriptor{
+	return &stringBuilder{desc: &Descriptor{
 		Name: name,
 		Info: &TypeInfo{Type: TypeString},
 	}}
 }
 
 // Text returns a new string field without limitation on the size.
-// In MySQL, it is the "longtext" type, but in SQLite and Gremlin it has no effect.
+// In MySQL, it is the "longtext" type, in PostgreSQL and SQLite it is
+// the "text" type, and in Gremlin it has no effect. Note that, length
+// validators (MinLen and MaxLen) are not supported by text fields.
 func Text(name string) *stringBuilder {
-	return &stringBuilder{&Descriptor{
+	return &stringBuilder{text: true, desc: &Descriptor{
 		Name: name,
 		Size: math.MaxInt32,
 		Info: &TypeInfo{Type: TypeString},
 	}}
 }
 
+// Char returns a new string field with a fixed length of n characters. In SQL dialects,
+// it is the "char(n)" type, and values are validated to be exactly n characters long.
+//
+//	field.Char("country_code", 2)
+//
+func Char(name string, n int) *stringBuilder {
+	t := fmt.Sprintf("char(%d)", n)
+	return String(name).
+		MaxLen(n).
+		MinLen(n).
+		SchemaType(map[string]string{
+			dialect.MySQL:    t,
+			dialect.Postgres: t,
+			dialect.SQLite:   t,
+		})
+}
+
 // Bytes returns a new Field with type bytes/buffer.
 // In MySQL and SQLite, it is the "BLOB" type, and it does not support for Gremlin.
 func Bytes(name string) *bytesBuilder {
@@ -166,6 +189,7 @@ func Other(name string, typ driver.Valuer) *otherBuilder {
 // stringBuilder is the builder for string fields.
 type stringBuilder struct {
 	desc *Descriptor
+	text bool // text fields do not support length limits.
 }
 
 // Unique makes the field unique within all vertices of this type.
@@ -180,6 +204,80 @@ func (b *stringBuilder) Sensitive() *stringBuilder {
 	return b
 }
 
+// SensitiveMask is like Sensitive, but the field is printed masked instead of being
+// omitted. The last n letters and digits of the value are kept, and the rest of them
+// are replaced with "*". Other characters (e.g. separators) are printed as-is.
+//
+//	field.String("ssn").
+//		SensitiveMask(4)	// ***-**-6789
+//
+func (b *stringBuilder) SensitiveMask(n int) *stringBuilder {
+	b.desc.Sensitive = true
+	b.desc.SensitiveMask = n
+	return b
+}
+
+// EncryptVersioned encrypts the field values before they are stored in the database, and
+// decrypts them when they are loaded. Values are encrypted with the current key of the given
+// store, and the version of the key is stored as a prefix of the ciphertext. Therefore, keys
+// can be rotated without re-encrypting the existing values.
+//
+//	field.String("ssn").
+//		EncryptVersioned(keystore)
+//
+func (b *stringBuilder) EncryptVersioned(ks KeyVersionStore) *stringBuilder {
+	if ks == nil {
+		b.desc.Err = fmt.Errorf("field.String(%q): EncryptVersioned requires a non-nil key store", b.desc.Name)
+	}
+	b.desc.Keys = ks
+	return b
+}
+
+// Compress compresses the field values using the given codec before they are stored in
+// the database, and decompresses them when they are loaded. Compressed values are stored
+// in a binary column, and an additional "<column>_compressed" boolean column is added to
+// the table for distinguishing them from legacy rows that were stored uncompressed.
+//
+//	field.Text("body").
+//		Compress(field.LZ4)
+//
+func (b *stringBuilder) Compress(c Compression) *stringBuilder {
+	if c == nil {
+		b.desc.Err = fmt.Errorf("field.String(%q): Compress requires a non-nil codec", b.desc.Name)
+	}
+	b.desc.Compression = c
+	return b
+}
+
+// AutoSlug generates the value of the field on creation from the given source field using
+// Slugify, if it was not set explicitly. If the generated slug is already taken by another
+// entity, a numeric suffix is appended to it (e.g. "post-title-2"). AutoSlug fields are unique.
+//
+//	field.String("title"),
+//	field.String("slug").
+//		AutoSlug("title")
+//
+func (b *stringBuilder) AutoSlug(source string) *stringBuilder {
+	b.desc.AutoSlug = source
+	b.desc.Unique = true
+	return b
+}
+
+// AuditBy records the user that set the field. When the field is set or cleared by a mutation,
+// the given user field is set to the user that is stored in the context of the mutation. The
+// context key of the user is configured by the AuditByContextKey variable of the generated
+// package.
+//
+//	field.String("status").
+//		AuditBy("updated_by"),
+//	field.Int("updated_by").
+//		Optional()
+//
+func (b *stringBuilder) AuditBy(userField string) *stringBuilder {
+	b.desc.AuditBy = userField
+	return b
+}
+
 // Match adds a regex matcher for this field. Operation fails if the regex fails.
 func (b *stringBuilder) Match(re *regexp.Regexp) *stringBuilder {
 	b.desc.Validators = append(b.desc.Validators, func(v string) error {
@@ -194,6 +292,21 @@ func (b *stringBuilder) Match(re *regexp.Regexp) *stringBuilder {
 // MinLen adds a length validator for this field.
 // Operation fails if the length of the string is less than the given value.
 func (b *stringBuilder) MinLen(i int) *stringBuilder {
+	if b.text {
+		b.desc.Err = fmt.Errorf("field.Text(%q): MinLen is not supported by text fields, use field.String instead", b.desc.Name)
+		return b
+	}
+	return b.minLen(i)
+}
+
+// NotEmpty adds a length validator for this field.
+// Operation fails if the length of the string is zero.
+func (b *stringBuilder) NotEmpty() *stringBuilder {
+	return b.minLen(1)
+}
+
+// minLen adds a validator for the minimum length of the string.
+func (b *stringBuilder) minLen(i int) *stringBuilder {
 	b.desc.Validators = append(b.desc.Validators, func(v string) error {
 		if len(v) < i {
 			return errors.New("value is less than the required length")
@@ -203,15 +316,13 @@ func (b *stringBuilder) MinLen(i int) *stringBuilder {
 	return b
 }
 
-// NotEmpty adds a length validator for this field.
-// Operation fails if the length of the string is zero.
-func (b *stringBuilder) NotEmpty() *stringBuilder {
-	return b.MinLen(1)
-}
-
 // MaxLen adds a length validator for this field.
 // Operation fails if the length of the string is greater than the given value.
 func (b *stringBuilder) MaxLen(i int) *stringBuilder {
+	if b.text {
+		b.desc.Err = fmt.Errorf("field.Text(%q): MaxLen is not supported by text fields, use field.String instead", b.desc.Name)
+		return b
+	}
 	b.desc.Size = i
 	b.desc.Validators = append(b.desc.Validators, func(v string) error {
 		if len(v) > i {
@@ -222,12 +333,68 @@ func (b *stringBuilder) MaxLen(i int) *stringBuilder {
 	return b
 }
 
+// MaxRuneCount adds a length validator for this field that counts the characters (runes) of
+// the string instead of its bytes, and therefore, handles multibyte Unicode values correctly.
+// The size of the column is set to the given value, that is defined in characters by the
+// VARCHAR type of MySQL and PostgreSQL.
+//
+//	field.String("username").
+//		MaxRuneCount(20)
+//
+func (b *stringBuilder) MaxRuneCount(i int) *stringBuilder {
+	if b.text {
+		b.desc.Err = fmt.Errorf("field.Text(%q): MaxRuneCount is not supported by text fields, use field.String instead", b.desc.Name)
+		return b
+	}
+	b.desc.Size = i
+	b.desc.Validators = append(b.desc.Validators, func(v string) error {
+		if utf8.RuneCountInString(v) > i {
+			return errors.New("value is greater than the required length")
+		}
+		return nil
+	})
+	return b
+}
+
+// Words adds a word count validator for this field. Operation fails if the number
+// of words (separated by whitespace) in the string is greater than the given value.
+// In MySQL and PostgreSQL, a CHECK constraint is added to the table as well.
+//
+//	field.Text("excerpt").
+//		Words(50)
+//
+func (b *stringBuilder) Words(i int) *stringBuilder {
+	b.desc.MaxWords = i
+	b.desc.Validators = append(b.desc.Validators, func(v string) error {
+		if len(strings.Fields(v)) > i {
+			return errors.New("value has more words than the allowed")
+		}
+		return nil
+	})
+	return b
+}
+
 // Validate adds a validator for this field. Operation fails if the validation fails.
 func (b *stringBuilder) Validate(fn func(string) error) *stringBuilder {
 	b.desc.Validators = append(b.desc.Validators, fn)
 	return b
 }
 
+// ContextValidation adds a validator for this field that requires the mutation context, for
+// example, for validators that query the database. Unlike Validate, that is called by the
+// builders before save, context validators are called by the client hooks on mutation
+// execution with the context of the mutation.
+//
+//	field.String("username").
+//		ContextValidation(func(ctx context.Context, s string) error {
+//			return checkUnique(ctx, s)
+//		})
+//
+func (b *stringBuilder) ContextValidation(fn func(context.Context, string) error) *stringBuilder {
+	b.desc.ContextValidators = append(b.desc.ContextValidators, fn)
+	return b
+}
+
 // Default sets the default value of the field.
 func (b *stringBuilder) Default(s string) *stringBuilder {
 	b.desc.Default = s
@@ -271,6 +438,13 @@ func (b *stringBuilder) Comment(c string) *stringBuilder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *stringBuilder) DisplayName(name string) *stringBuilder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // StructTag sets the struct tag of the field.
 func (b *stringBuilder) StructTag(s string) *stringBuilder {
 	b.desc.Tag = s
@@ -326,6 +500,15 @@ func (b *stringBuilder) Descriptor() *Descriptor {
 	if b.desc.Default != nil {
 		b.desc.checkDefaultFunc(stringType)
 	}
+	if b.desc.Phone && b.desc.Info.RType != nil {
+		b.desc.Err = fmt.Errorf("field.Phone(%q): GoType is not supported by phone fields", b.desc.Name)
+	}
+	if b.desc.URL && b.desc.Info.RType != nil {
+		b.desc.Err = fmt.Errorf("field.URL(%q): GoType is not supported by url fields", b.desc.Name)
+	}
+	if b.desc.Locale != "" {
+		b.desc.checkLocale()
+	}
 	return b.desc
 }
 
@@ -361,6 +544,13 @@ func (b *timeBuilder) Comment(c string) *timeBuilder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *timeBuilder) DisplayName(name string) *timeBuilder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // StructTag sets the struct tag of the field.
 func (b *timeBuilder) StructTag(s string) *timeBuilder {
 	b.desc.Tag = s
@@ -484,6 +674,13 @@ func (b *boolBuilder) Comment(c string) *boolBuilder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *boolBuilder) DisplayName(name string) *boolBuilder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // StructTag sets the struct tag of the field.
 func (b *boolBuilder) StructTag(s string) *boolBuilder {
 	b.desc.Tag = s
@@ -567,6 +764,21 @@ func (b *bytesBuilder) Sensitive() *bytesBuilder {
 	return b
 }
 
+// Compress compresses the field values using the given codec before they are stored in
+// the database, and decompresses them when they are loaded. See stringBuilder.Compress
+// for more info.
+//
+//	field.Bytes("document").
+//		Compress(field.LZ4)
+//
+func (b *bytesBuilder) Compress(c Compression) *bytesBuilder {
+	if c == nil {
+		b.desc.Err = fmt.Errorf("field.Bytes(%q): Compress requires a non-nil codec", b.desc.Name)
+	}
+	b.desc.Compression = c
+	return b
+}
+
 // Unique makes the field unique within all vertices of this type.
 // Only supported in PostgreSQL.
 func (b *bytesBuilder) Unique() *bytesBuilder {
@@ -586,6 +798,13 @@ func (b *bytesBuilder) Comment(c string) *bytesBuilder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *bytesBuilder) DisplayName(name string) *bytesBuilder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // StructTag sets the struct tag of the field.
 func (b *bytesBuilder) StructTag(s string) *bytesBuilder {
 	b.desc.Tag = s
@@ -716,6 +935,13 @@ func (b *jsonBuilder) Comment(c string) *jsonBuilder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *jsonBuilder) DisplayName(name string) *jsonBuilder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // Sensitive fields not printable and not serializable.
 func (b *jsonBuilder) Sensitive() *jsonBuilder {
 	b.desc.Sensitive = true
@@ -849,6 +1075,25 @@ func (b *enumBuilder) Comment(c string) *enumBuilder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *enumBuilder) DisplayName(name string) *enumBuilder {
+	b.desc.DisplayName = name
+	return b
+}
+
+// AuditBy records the user that set the field. When the field is set or cleared by a mutation,
+// the given user field is set to the user that is stored in the context of the mutation.
+//
+//	field.Enum("status").
+//		Values("active", "inactive").
+//		AuditBy("updated_by")
+//
+func (b *enumBuilder) AuditBy(userField string) *enumBuilder {
+	b.desc.AuditBy = userField
+	return b
+}
+
 // Nillable indicates that this field is a nillable.
 // Unlike "Optional" only fields, "Nillable" fields are pointers in the generated struct.
 func (b *enumBuilder) Nillable() *enumBuilder {
@@ -959,6 +1204,13 @@ func (b *uuidBuilder) Comment(c string) *uuidBuilder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *uuidBuilder) DisplayName(name string) *uuidBuilder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // StructTag sets the struct tag of the field.
 func (b *uuidBuilder) StructTag(s string) *uuidBuilder {
 	b.desc.Tag = s
@@ -1085,6 +1337,13 @@ func (b *otherBuilder) Comment(c string) *otherBuilder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *otherBuilder) DisplayName(name string) *otherBuilder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // StructTag sets the struct tag of the field.
 func (b *otherBuilder) StructTag(s string) *otherBuilder {
 	b.desc.Tag = s
@@ -1139,24 +1398,39 @@ func (b *otherBuilder) Descriptor() *Descriptor {
 
 // A Descriptor for field configuration.
 type Descriptor struct {
-	Tag           string                  // struct tag.
-	Size          int                     // varchar size.
-	Name          string                  // field name.
-	Info          *TypeInfo               // field type info.
-	Unique        bool                    // unique index of field.
-	Nillable      bool                    // nillable struct field.
-	Optional      bool                    // nullable field in database.
-	Immutable     bool                    // create-only field.
-	Default       interface{}             // default value on create.
-	UpdateDefault interface{}             // default value on update.
-	Validators    []interface{}           // validator functions.
-	StorageKey    string                  // sql column or gremlin property.
-	Enums         []struct{ N, V string } // enum values.
-	Sensitive     bool                    // sensitive info string field.
-	SchemaType    map[string]string       // override the schema type.
-	Annotations   []schema.Annotation     // field annotations.
-	Comment       string                  // field comment.
-	Err           error
+	Tag               string                  // struct tag.
+	Size              int                     // varchar size.
+	Name              string                  // field name.
+	Info              *TypeInfo               // field type info.
+	Unique            bool                    // unique index of field.
+	Nillable          bool                    // nillable struct field.
+	Optional          bool                    // nullable field in database.
+	Immutable         bool                    // create-only field.
+	Default           interface{}             // default value on create.
+	UpdateDefault     interface{}             // default value on update.
+	Validators        []interface{}           // validator functions.
+	ContextValidators []interface{}           // context validator functions.
+	StorageKey        string                  // sql column or gremlin property.
+	Enums             []struct{ N, V string } // enum values.
+	Sensitive         bool                    // sensitive info string field.
+	SchemaType        map[string]string       // override the schema type.
+	Annotations       []schema.Annotation     // field annotations.
+	Comment           string                  // field comment.
+	DisplayName       string                  // human-readable field name.
+	MaxWords          int                     // max number of words.
+	SensitiveMask     int                     // visible characters of sensitive value.
+	AutoSlug          string                  // slug source field.
+	AuditBy           string                  // audit user field.
+	Phone             bool                    // phone number field.
+	URL               bool                    // url field.
+	Array             bool                    // postgres array field.
+	Counter           bool                    // counter field that can only be incremented on update.
+	HumanReadable     bool                    // human-readable duration field.
+	Locale            string                  // default locale of translations field.
+	Keys              KeyVersionStore         // encryption keys.
+	Compression       Compression             // compression codec.
+	ZeroValue         interface{}             // go value of null column.
+	Err               error
 }
 
 func (d *Descriptor) goType(typ interface{}, expectType reflect.Type) {
@@ -1177,7 +1451,11 @@ func (d *Descriptor) goType(typ interface{}, expectType reflect.Type) {
 	}
 	methods(t, info.RType)
 	switch t.Kind() {
-	case reflect.Slice, reflect.Ptr, reflect.Map:
+	case reflect.Slice:
+		et := t.Elem()
+		info.RType.Elem = &RType{rtype: et, Name: et.Name(), Ident: et.String(), Kind: et.Kind(), PkgPath: et.PkgPath()}
+		info.Nillable = true
+	case reflect.Ptr, reflect.Map:
 		info.Nillable = true
 	}
 	switch pt := reflect.PtrTo(t); {
@@ -1231,6 +1509,24 @@ func (d *Descriptor) checkDefaultFunc(expectType reflect.Type) {
 	}
 }
 
+// checkZeroValue checks that the GoZeroValue matches the field type, and
+// that it is used on an optional field.
+func (d *Descriptor) checkZeroValue(expectType reflect.Type) {
+	rtype := expectType
+	if d.Info.RType != nil {
+		rtype = d.Info.RType.rtype
+	}
+	switch {
+	case d.Err != nil:
+	case reflect.TypeOf(d.ZeroValue) != rtype:
+		d.Err = fmt.Errorf("expect type (%s) for zero value", d.Info)
+	case !d.Optional:
+		d.Err = errors.New("GoZeroValue requires an Optional field")
+	case d.Info.ValueScanner():
+		d.Err = errors.New("GoZeroValue is not supported for types that implement the ValueScanner interface")
+	}
+}
+
 var (
 	boolType         = reflect.TypeOf(false)
 	bytesType        = reflect.TypeOf([]byte(nil))
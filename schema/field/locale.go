@@ -0,0 +1,62 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Locale stores the translations of the field as a JSON map from their locale to their
+// value (e.g. {"en": "Hello", "fr": "Bonjour"}), and therefore, the field is generated
+// with the map[string]string Go type. The generated code provides methods for getting,
+// setting and filtering the translation of a specific locale, and the default locale
+// is used as a fallback when the requested translation does not exist.
+//
+//	field.String("title").
+//		Locale("en")
+//
+func (b *stringBuilder) Locale(defaultLocale string) *stringBuilder {
+	if defaultLocale == "" {
+		b.desc.Err = fmt.Errorf("field.String(%q): Locale requires a non-empty default locale", b.desc.Name)
+	}
+	b.desc.Locale = defaultLocale
+	return b
+}
+
+var localeType = reflect.TypeOf(map[string]string(nil))
+
+// checkLocale checks that the localized field does not use options that
+// are applied on string values, and sets its type to a JSON map.
+func (d *Descriptor) checkLocale() {
+	// Type was already set by a previous call.
+	if d.Err != nil || d.Info.Type == TypeJSON {
+		return
+	}
+	var option string
+	switch {
+	case d.Info.RType != nil:
+		option = "GoType"
+	case d.Unique:
+		option = "Unique"
+	case d.Nillable:
+		option = "Nillable"
+	case d.Default != nil || d.UpdateDefault != nil:
+		option = "Default"
+	case len(d.Validators) > 0 || len(d.ContextValidators) > 0:
+		option = "Validate"
+	case d.Keys != nil:
+		option = "EncryptVersioned"
+	case d.Compression != nil:
+		option = "Compress"
+	}
+	if option != "" {
+		d.Err = fmt.Errorf("field.String(%q): %s is not supported by localized fields", d.Name, option)
+		return
+	}
+	d.Size = 0
+	d.Info = &TypeInfo{Type: TypeJSON}
+	d.goType(map[string]string{}, localeType)
+}
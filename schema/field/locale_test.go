@@ -0,0 +1,43 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field_test
+
+import (
+	"testing"
+
+	"entgo.io/ent/schema/field"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocale(t *testing.T) {
+	fd := field.String("title").
+		Locale("en").
+		Optional().
+		Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, "en", fd.Locale)
+	assert.True(t, fd.Optional)
+	assert.Equal(t, field.TypeJSON, fd.Info.Type)
+	assert.Equal(t, "map[string]string", fd.Info.Ident)
+	assert.True(t, fd.Info.Nillable)
+	assert.NotNil(t, fd.Info.RType)
+
+	fd = field.Text("body").Locale("en").Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Zero(t, fd.Size)
+
+	fd = field.String("title").Locale("").Descriptor()
+	assert.EqualError(t, fd.Err, `field.String("title"): Locale requires a non-empty default locale`)
+
+	fd = field.String("title").Locale("en").Unique().Descriptor()
+	assert.EqualError(t, fd.Err, `field.String("title"): Unique is not supported by localized fields`)
+
+	fd = field.String("title").Locale("en").NotEmpty().Descriptor()
+	assert.EqualError(t, fd.Err, `field.String("title"): Validate is not supported by localized fields`)
+
+	fd = field.String("title").Locale("en").Default("a").Descriptor()
+	assert.EqualError(t, fd.Err, `field.String("title"): Default is not supported by localized fields`)
+}
@@ -7,6 +7,7 @@
 package field
 
 import (
+	"context"
 	"errors"
 	"reflect"
 
@@ -208,6 +209,13 @@ func (b *intBuilder) Comment(c string) *intBuilder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *intBuilder) DisplayName(name string) *intBuilder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // Optional indicates that this field is optional on create.
 // Unlike edges, fields are required by default.
 func (b *intBuilder) Optional() *intBuilder {
@@ -233,6 +241,27 @@ func (b *intBuilder) Validate(fn func(int) error) *intBuilder {
 	return b
 }
 
+// ContextValidation adds a validator for this field that requires the mutation context, for
+// example, for validators that query the database. Unlike Validate, that is called by the
+// builders before save, context validators are called by the client hooks on mutation
+// execution with the context of the mutation.
+func (b *intBuilder) ContextValidation(fn func(context.Context, int) error) *intBuilder {
+	b.desc.ContextValidators = append(b.desc.ContextValidators, fn)
+	return b
+}
+
+// Counter marks the field as a counter that can only be incremented on updates. The
+// update builders of the field are generated with an Incr method instead of its Set and
+// Add methods, and updates that set the field or decrement it are rejected.
+//
+//	field.Int("views").
+//		Counter()
+//
+func (b *intBuilder) Counter() *intBuilder {
+	b.desc.Counter = true
+	return b
+}
+
 // StorageKey sets the storage key of the field.
 // In SQL dialects is the column name and Gremlin is the property.
 func (b *intBuilder) StorageKey(key string) *intBuilder {
@@ -271,6 +300,20 @@ func (b *intBuilder) GoType(typ interface{}) *intBuilder {
 	return b
 }
 
+// GoZeroValue sets the Go value that represents a NULL value of this optional
+// field. NULL columns are read into the entity as this value, and setting the
+// field to it stores NULL in the database.
+//
+//	field.Int("int").
+//		GoType(pkg.Int(0)).
+//		GoZeroValue(pkg.Int(-1)).
+//		Optional()
+//
+func (b *intBuilder) GoZeroValue(v interface{}) *intBuilder {
+	b.desc.ZeroValue = v
+	return b
+}
+
 // Annotations adds a list of annotations to the field object to be used by
 // codegen extensions.
 //
@@ -287,6 +330,9 @@ func (b *intBuilder) Descriptor() *Descriptor {
 	if b.desc.Default != nil || b.desc.UpdateDefault != nil {
 		b.desc.checkDefaultFunc(intType)
 	}
+	if b.desc.ZeroValue != nil {
+		b.desc.checkZeroValue(intType)
+	}
 	return b.desc
 }
 
@@ -377,6 +423,13 @@ func (b *uintBuilder) Comment(c string) *uintBuilder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *uintBuilder) DisplayName(name string) *uintBuilder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // Optional indicates that this field is optional on create.
 // Unlike edges, fields are required by default.
 func (b *uintBuilder) Optional() *uintBuilder {
@@ -402,6 +455,27 @@ func (b *uintBuilder) Validate(fn func(uint) error) *uintBuilder {
 	return b
 }
 
+// ContextValidation adds a validator for this field that requires the mutation context, for
+// example, for validators that query the database. Unlike Validate, that is called by the
+// builders before save, context validators are called by the client hooks on mutation
+// execution with the context of the mutation.
+func (b *uintBuilder) ContextValidation(fn func(context.Context, uint) error) *uintBuilder {
+	b.desc.ContextValidators = append(b.desc.ContextValidators, fn)
+	return b
+}
+
+// Counter marks the field as a counter that can only be incremented on updates. The
+// update builders of the field are generated with an Incr method instead of its Set and
+// Add methods, and updates that set the field or decrement it are rejected.
+//
+//	field.Uint("views").
+//		Counter()
+//
+func (b *uintBuilder) Counter() *uintBuilder {
+	b.desc.Counter = true
+	return b
+}
+
 // StorageKey sets the storage key of the field.
 // In SQL dialects is the column name and Gremlin is the property.
 func (b *uintBuilder) StorageKey(key string) *uintBuilder {
@@ -440,6 +514,20 @@ func (b *uintBuilder) GoType(typ interface{}) *uintBuilder {
 	return b
 }
 
+// GoZeroValue sets the Go value that represents a NULL value of this optional
+// field. NULL columns are read into the entity as this value, and setting the
+// field to it stores NULL in the database.
+//
+//	field.Uint("uint").
+//		GoType(pkg.Uint(0)).
+//		GoZeroValue(pkg.Uint(math.MaxUint)).
+//		Optional()
+//
+func (b *uintBuilder) GoZeroValue(v interface{}) *uintBuilder {
+	b.desc.ZeroValue = v
+	return b
+}
+
 // Annotations adds a list of annotations to the field object to be used by
 // codegen extensions.
 //
@@ -456,6 +544,9 @@ func (b *uintBuilder) Descriptor() *Descriptor {
 	if b.desc.Default != nil || b.desc.UpdateDefault != nil {
 		b.desc.checkDefaultFunc(uintType)
 	}
+	if b.desc.ZeroValue != nil {
+		b.desc.checkZeroValue(uintType)
+	}
 	return b.desc
 }
 
@@ -556,6 +647,13 @@ func (b *int8Builder) Comment(c string) *int8Builder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *int8Builder) DisplayName(name string) *int8Builder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // Optional indicates that this field is optional on create.
 // Unlike edges, fields are required by default.
 func (b *int8Builder) Optional() *int8Builder {
@@ -581,6 +679,27 @@ func (b *int8Builder) Validate(fn func(int8) error) *int8Builder {
 	return b
 }
 
+// ContextValidation adds a validator for this field that requires the mutation context, for
+// example, for validators that query the database. Unlike Validate, that is called by the
+// builders before save, context validators are called by the client hooks on mutation
+// execution with the context of the mutation.
+func (b *int8Builder) ContextValidation(fn func(context.Context, int8) error) *int8Builder {
+	b.desc.ContextValidators = append(b.desc.ContextValidators, fn)
+	return b
+}
+
+// Counter marks the field as a counter that can only be incremented on updates. The
+// update builders of the field are generated with an Incr method instead of its Set and
+// Add methods, and updates that set the field or decrement it are rejected.
+//
+//	field.Int8("views").
+//		Counter()
+//
+func (b *int8Builder) Counter() *int8Builder {
+	b.desc.Counter = true
+	return b
+}
+
 // StorageKey sets the storage key of the field.
 // In SQL dialects is the column name and Gremlin is the property.
 func (b *int8Builder) StorageKey(key string) *int8Builder {
@@ -619,6 +738,20 @@ func (b *int8Builder) GoType(typ interface{}) *int8Builder {
 	return b
 }
 
+// GoZeroValue sets the Go value that represents a NULL value of this optional
+// field. NULL columns are read into the entity as this value, and setting the
+// field to it stores NULL in the database.
+//
+//	field.Int8("int8").
+//		GoType(pkg.Int8(0)).
+//		GoZeroValue(pkg.Int8(-1)).
+//		Optional()
+//
+func (b *int8Builder) GoZeroValue(v interface{}) *int8Builder {
+	b.desc.ZeroValue = v
+	return b
+}
+
 // Annotations adds a list of annotations to the field object to be used by
 // codegen extensions.
 //
@@ -635,6 +768,9 @@ func (b *int8Builder) Descriptor() *Descriptor {
 	if b.desc.Default != nil || b.desc.UpdateDefault != nil {
 		b.desc.checkDefaultFunc(int8Type)
 	}
+	if b.desc.ZeroValue != nil {
+		b.desc.checkZeroValue(int8Type)
+	}
 	return b.desc
 }
 
@@ -735,6 +871,13 @@ func (b *int16Builder) Comment(c string) *int16Builder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *int16Builder) DisplayName(name string) *int16Builder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // Optional indicates that this field is optional on create.
 // Unlike edges, fields are required by default.
 func (b *int16Builder) Optional() *int16Builder {
@@ -760,6 +903,27 @@ func (b *int16Builder) Validate(fn func(int16) error) *int16Builder {
 	return b
 }
 
+// ContextValidation adds a validator for this field that requires the mutation context, for
+// example, for validators that query the database. Unlike Validate, that is called by the
+// builders before save, context validators are called by the client hooks on mutation
+// execution with the context of the mutation.
+func (b *int16Builder) ContextValidation(fn func(context.Context, int16) error) *int16Builder {
+	b.desc.ContextValidators = append(b.desc.ContextValidators, fn)
+	return b
+}
+
+// Counter marks the field as a counter that can only be incremented on updates. The
+// update builders of the field are generated with an Incr method instead of its Set and
+// Add methods, and updates that set the field or decrement it are rejected.
+//
+//	field.Int16("views").
+//		Counter()
+//
+func (b *int16Builder) Counter() *int16Builder {
+	b.desc.Counter = true
+	return b
+}
+
 // StorageKey sets the storage key of the field.
 // In SQL dialects is the column name and Gremlin is the property.
 func (b *int16Builder) StorageKey(key string) *int16Builder {
@@ -798,6 +962,20 @@ func (b *int16Builder) GoType(typ interface{}) *int16Builder {
 	return b
 }
 
+// GoZeroValue sets the Go value that represents a NULL value of this optional
+// field. NULL columns are read into the entity as this value, and setting the
+// field to it stores NULL in the database.
+//
+//	field.Int16("int16").
+//		GoType(pkg.Int16(0)).
+//		GoZeroValue(pkg.Int16(-1)).
+//		Optional()
+//
+func (b *int16Builder) GoZeroValue(v interface{}) *int16Builder {
+	b.desc.ZeroValue = v
+	return b
+}
+
 // Annotations adds a list of annotations to the field object to be used by
 // codegen extensions.
 //
@@ -814,6 +992,9 @@ func (b *int16Builder) Descriptor() *Descriptor {
 	if b.desc.Default != nil || b.desc.UpdateDefault != nil {
 		b.desc.checkDefaultFunc(int16Type)
 	}
+	if b.desc.ZeroValue != nil {
+		b.desc.checkZeroValue(int16Type)
+	}
 	return b.desc
 }
 
@@ -914,6 +1095,13 @@ func (b *int32Builder) Comment(c string) *int32Builder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *int32Builder) DisplayName(name string) *int32Builder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // Optional indicates that this field is optional on create.
 // Unlike edges, fields are required by default.
 func (b *int32Builder) Optional() *int32Builder {
@@ -939,6 +1127,27 @@ func (b *int32Builder) Validate(fn func(int32) error) *int32Builder {
 	return b
 }
 
+// ContextValidation adds a validator for this field that requires the mutation context, for
+// example, for validators that query the database. Unlike Validate, that is called by the
+// builders before save, context validators are called by the client hooks on mutation
+// execution with the context of the mutation.
+func (b *int32Builder) ContextValidation(fn func(context.Context, int32) error) *int32Builder {
+	b.desc.ContextValidators = append(b.desc.ContextValidators, fn)
+	return b
+}
+
+// Counter marks the field as a counter that can only be incremented on updates. The
+// update builders of the field are generated with an Incr method instead of its Set and
+// Add methods, and updates that set the field or decrement it are rejected.
+//
+//	field.Int32("views").
+//		Counter()
+//
+func (b *int32Builder) Counter() *int32Builder {
+	b.desc.Counter = true
+	return b
+}
+
 // StorageKey sets the storage key of the field.
 // In SQL dialects is the column name and Gremlin is the property.
 func (b *int32Builder) StorageKey(key string) *int32Builder {
@@ -977,6 +1186,20 @@ func (b *int32Builder) GoType(typ interface{}) *int32Builder {
 	return b
 }
 
+// GoZeroValue sets the Go value that represents a NULL value of this optional
+// field. NULL columns are read into the entity as this value, and setting the
+// field to it stores NULL in the database.
+//
+//	field.Int32("int32").
+//		GoType(pkg.Int32(0)).
+//		GoZeroValue(pkg.Int32(-1)).
+//		Optional()
+//
+func (b *int32Builder) GoZeroValue(v interface{}) *int32Builder {
+	b.desc.ZeroValue = v
+	return b
+}
+
 // Annotations adds a list of annotations to the field object to be used by
 // codegen extensions.
 //
@@ -993,6 +1216,9 @@ func (b *int32Builder) Descriptor() *Descriptor {
 	if b.desc.Default != nil || b.desc.UpdateDefault != nil {
 		b.desc.checkDefaultFunc(int32Type)
 	}
+	if b.desc.ZeroValue != nil {
+		b.desc.checkZeroValue(int32Type)
+	}
 	return b.desc
 }
 
@@ -1093,6 +1319,13 @@ func (b *int64Builder) Comment(c string) *int64Builder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *int64Builder) DisplayName(name string) *int64Builder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // Optional indicates that this field is optional on create.
 // Unlike edges, fields are required by default.
 func (b *int64Builder) Optional() *int64Builder {
@@ -1118,6 +1351,40 @@ func (b *int64Builder) Validate(fn func(int64) error) *int64Builder {
 	return b
 }
 
+// ContextValidation adds a validator for this field that requires the mutation context, for
+// example, for validators that query the database. Unlike Validate, that is called by the
+// builders before save, context validators are called by the client hooks on mutation
+// execution with the context of the mutation.
+func (b *int64Builder) ContextValidation(fn func(context.Context, int64) error) *int64Builder {
+	b.desc.ContextValidators = append(b.desc.ContextValidators, fn)
+	return b
+}
+
+// Counter marks the field as a counter that can only be incremented on updates. The
+// update builders of the field are generated with an Incr method instead of its Set and
+// Add methods, and updates that set the field or decrement it are rejected.
+//
+//	field.Int64("views").
+//		Counter()
+//
+func (b *int64Builder) Counter() *int64Builder {
+	b.desc.Counter = true
+	return b
+}
+
+// HumanReadable marks the field as a duration that is stored in nanoseconds and displayed
+// in its human-readable form. The generated entities have an additional <Field>Human method
+// that formats the value using time.Duration.String (e.g. "5m30s"), and the builders have an
+// additional Set<Field>FromHuman method that parses the value using time.ParseDuration.
+//
+//	field.Duration("timeout").
+//		HumanReadable()
+//
+func (b *int64Builder) HumanReadable() *int64Builder {
+	b.desc.HumanReadable = true
+	return b
+}
+
 // StorageKey sets the storage key of the field.
 // In SQL dialects is the column name and Gremlin is the property.
 func (b *int64Builder) StorageKey(key string) *int64Builder {
@@ -1156,6 +1423,20 @@ func (b *int64Builder) GoType(typ interface{}) *int64Builder {
 	return b
 }
 
+// GoZeroValue sets the Go value that represents a NULL value of this optional
+// field. NULL columns are read into the entity as this value, and setting the
+// field to it stores NULL in the database.
+//
+//	field.Int64("int64").
+//		GoType(pkg.Int64(0)).
+//		GoZeroValue(pkg.Int64(-1)).
+//		Optional()
+//
+func (b *int64Builder) GoZeroValue(v interface{}) *int64Builder {
+	b.desc.ZeroValue = v
+	return b
+}
+
 // Annotations adds a list of annotations to the field object to be used by
 // codegen extensions.
 //
@@ -1172,6 +1453,12 @@ func (b *int64Builder) Descriptor() *Descriptor {
 	if b.desc.Default != nil || b.desc.UpdateDefault != nil {
 		b.desc.checkDefaultFunc(int64Type)
 	}
+	if b.desc.ZeroValue != nil {
+		b.desc.checkZeroValue(int64Type)
+	}
+	if b.desc.HumanReadable {
+		b.desc.checkHumanReadable()
+	}
 	return b.desc
 }
 
@@ -1262,6 +1549,13 @@ func (b *uint8Builder) Comment(c string) *uint8Builder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *uint8Builder) DisplayName(name string) *uint8Builder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // Optional indicates that this field is optional on create.
 // Unlike edges, fields are required by default.
 func (b *uint8Builder) Optional() *uint8Builder {
@@ -1287,6 +1581,27 @@ func (b *uint8Builder) Validate(fn func(uint8) error) *uint8Builder {
 	return b
 }
 
+// ContextValidation adds a validator for this field that requires the mutation context, for
+// example, for validators that query the database. Unlike Validate, that is called by the
+// builders before save, context validators are called by the client hooks on mutation
+// execution with the context of the mutation.
+func (b *uint8Builder) ContextValidation(fn func(context.Context, uint8) error) *uint8Builder {
+	b.desc.ContextValidators = append(b.desc.ContextValidators, fn)
+	return b
+}
+
+// Counter marks the field as a counter that can only be incremented on updates. The
+// update builders of the field are generated with an Incr method instead of its Set and
+// Add methods, and updates that set the field or decrement it are rejected.
+//
+//	field.Uint8("views").
+//		Counter()
+//
+func (b *uint8Builder) Counter() *uint8Builder {
+	b.desc.Counter = true
+	return b
+}
+
 // StorageKey sets the storage key of the field.
 // In SQL dialects is the column name and Gremlin is the property.
 func (b *uint8Builder) StorageKey(key string) *uint8Builder {
@@ -1325,6 +1640,20 @@ func (b *uint8Builder) GoType(typ interface{}) *uint8Builder {
 	return b
 }
 
+// GoZeroValue sets the Go value that represents a NULL value of this optional
+// field. NULL columns are read into the entity as this value, and setting the
+// field to it stores NULL in the database.
+//
+//	field.Uint8("uint8").
+//		GoType(pkg.Uint8(0)).
+//		GoZeroValue(pkg.Uint8(math.MaxUint8)).
+//		Optional()
+//
+func (b *uint8Builder) GoZeroValue(v interface{}) *uint8Builder {
+	b.desc.ZeroValue = v
+	return b
+}
+
 // Annotations adds a list of annotations to the field object to be used by
 // codegen extensions.
 //
@@ -1341,6 +1670,9 @@ func (b *uint8Builder) Descriptor() *Descriptor {
 	if b.desc.Default != nil || b.desc.UpdateDefault != nil {
 		b.desc.checkDefaultFunc(uint8Type)
 	}
+	if b.desc.ZeroValue != nil {
+		b.desc.checkZeroValue(uint8Type)
+	}
 	return b.desc
 }
 
@@ -1431,6 +1763,13 @@ func (b *uint16Builder) Comment(c string) *uint16Builder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *uint16Builder) DisplayName(name string) *uint16Builder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // Optional indicates that this field is optional on create.
 // Unlike edges, fields are required by default.
 func (b *uint16Builder) Optional() *uint16Builder {
@@ -1456,6 +1795,27 @@ func (b *uint16Builder) Validate(fn func(uint16) error) *uint16Builder {
 	return b
 }
 
+// ContextValidation adds a validator for this field that requires the mutation context, for
+// example, for validators that query the database. Unlike Validate, that is called by the
+// builders before save, context validators are called by the client hooks on mutation
+// execution with the context of the mutation.
+func (b *uint16Builder) ContextValidation(fn func(context.Context, uint16) error) *uint16Builder {
+	b.desc.ContextValidators = append(b.desc.ContextValidators, fn)
+	return b
+}
+
+// Counter marks the field as a counter that can only be incremented on updates. The
+// update builders of the field are generated with an Incr method instead of its Set and
+// Add methods, and updates that set the field or decrement it are rejected.
+//
+//	field.Uint16("views").
+//		Counter()
+//
+func (b *uint16Builder) Counter() *uint16Builder {
+	b.desc.Counter = true
+	return b
+}
+
 // StorageKey sets the storage key of the field.
 // In SQL dialects is the column name and Gremlin is the property.
 func (b *uint16Builder) StorageKey(key string) *uint16Builder {
@@ -1494,6 +1854,20 @@ func (b *uint16Builder) GoType(typ interface{}) *uint16Builder {
 	return b
 }
 
+// GoZeroValue sets the Go value that represents a NULL value of this optional
+// field. NULL columns are read into the entity as this value, and setting the
+// field to it stores NULL in the database.
+//
+//	field.Uint16("uint16").
+//		GoType(pkg.Uint16(0)).
+//		GoZeroValue(pkg.Uint16(math.MaxUint16)).
+//		Optional()
+//
+func (b *uint16Builder) GoZeroValue(v interface{}) *uint16Builder {
+	b.desc.ZeroValue = v
+	return b
+}
+
 // Annotations adds a list of annotations to the field object to be used by
 // codegen extensions.
 //
@@ -1510,6 +1884,9 @@ func (b *uint16Builder) Descriptor() *Descriptor {
 	if b.desc.Default != nil || b.desc.UpdateDefault != nil {
 		b.desc.checkDefaultFunc(uint16Type)
 	}
+	if b.desc.ZeroValue != nil {
+		b.desc.checkZeroValue(uint16Type)
+	}
 	return b.desc
 }
 
@@ -1600,6 +1977,13 @@ func (b *uint32Builder) Comment(c string) *uint32Builder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *uint32Builder) DisplayName(name string) *uint32Builder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // Optional indicates that this field is optional on create.
 // Unlike edges, fields are required by default.
 func (b *uint32Builder) Optional() *uint32Builder {
@@ -1625,6 +2009,27 @@ func (b *uint32Builder) Validate(fn func(uint32) error) *uint32Builder {
 	return b
 }
 
+// ContextValidation adds a validator for this field that requires the mutation context, for
+// example, for validators that query the database. Unlike Validate, that is called by the
+// builders before save, context validators are called by the client hooks on mutation
+// execution with the context of the mutation.
+func (b *uint32Builder) ContextValidation(fn func(context.Context, uint32) error) *uint32Builder {
+	b.desc.ContextValidators = append(b.desc.ContextValidators, fn)
+	return b
+}
+
+// Counter marks the field as a counter that can only be incremented on updates. The
+// update builders of the field are generated with an Incr method instead of its Set and
+// Add methods, and updates that set the field or decrement it are rejected.
+//
+//	field.Uint32("views").
+//		Counter()
+//
+func (b *uint32Builder) Counter() *uint32Builder {
+	b.desc.Counter = true
+	return b
+}
+
 // StorageKey sets the storage key of the field.
 // In SQL dialects is the column name and Gremlin is the property.
 func (b *uint32Builder) StorageKey(key string) *uint32Builder {
@@ -1663,6 +2068,20 @@ func (b *uint32Builder) GoType(typ interface{}) *uint32Builder {
 	return b
 }
 
+// GoZeroValue sets the Go value that represents a NULL value of this optional
+// field. NULL columns are read into the entity as this value, and setting the
+// field to it stores NULL in the database.
+//
+//	field.Uint32("uint32").
+//		GoType(pkg.Uint32(0)).
+//		GoZeroValue(pkg.Uint32(math.MaxUint32)).
+//		Optional()
+//
+func (b *uint32Builder) GoZeroValue(v interface{}) *uint32Builder {
+	b.desc.ZeroValue = v
+	return b
+}
+
 // Annotations adds a list of annotations to the field object to be used by
 // codegen extensions.
 //
@@ -1679,6 +2098,9 @@ func (b *uint32Builder) Descriptor() *Descriptor {
 	if b.desc.Default != nil || b.desc.UpdateDefault != nil {
 		b.desc.checkDefaultFunc(uint32Type)
 	}
+	if b.desc.ZeroValue != nil {
+		b.desc.checkZeroValue(uint32Type)
+	}
 	return b.desc
 }
 
@@ -1769,6 +2191,13 @@ func (b *uint64Builder) Comment(c string) *uint64Builder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *uint64Builder) DisplayName(name string) *uint64Builder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // Optional indicates that this field is optional on create.
 // Unlike edges, fields are required by default.
 func (b *uint64Builder) Optional() *uint64Builder {
@@ -1794,6 +2223,27 @@ func (b *uint64Builder) Validate(fn func(uint64) error) *uint64Builder {
 	return b
 }
 
+// ContextValidation adds a validator for this field that requires the mutation context, for
+// example, for validators that query the database. Unlike Validate, that is called by the
+// builders before save, context validators are called by the client hooks on mutation
+// execution with the context of the mutation.
+func (b *uint64Builder) ContextValidation(fn func(context.Context, uint64) error) *uint64Builder {
+	b.desc.ContextValidators = append(b.desc.ContextValidators, fn)
+	return b
+}
+
+// Counter marks the field as a counter that can only be incremented on updates. The
+// update builders of the field are generated with an Incr method instead of its Set and
+// Add methods, and updates that set the field or decrement it are rejected.
+//
+//	field.Uint64("views").
+//		Counter()
+//
+func (b *uint64Builder) Counter() *uint64Builder {
+	b.desc.Counter = true
+	return b
+}
+
 // StorageKey sets the storage key of the field.
 // In SQL dialects is the column name and Gremlin is the property.
 func (b *uint64Builder) StorageKey(key string) *uint64Builder {
@@ -1832,6 +2282,20 @@ func (b *uint64Builder) GoType(typ interface{}) *uint64Builder {
 	return b
 }
 
+// GoZeroValue sets the Go value that represents a NULL value of this optional
+// field. NULL columns are read into the entity as this value, and setting the
+// field to it stores NULL in the database.
+//
+//	field.Uint64("uint64").
+//		GoType(pkg.Uint64(0)).
+//		GoZeroValue(pkg.Uint64(math.MaxUint64)).
+//		Optional()
+//
+func (b *uint64Builder) GoZeroValue(v interface{}) *uint64Builder {
+	b.desc.ZeroValue = v
+	return b
+}
+
 // Annotations adds a list of annotations to the field object to be used by
 // codegen extensions.
 //
@@ -1848,6 +2312,9 @@ func (b *uint64Builder) Descriptor() *Descriptor {
 	if b.desc.Default != nil || b.desc.UpdateDefault != nil {
 		b.desc.checkDefaultFunc(uint64Type)
 	}
+	if b.desc.ZeroValue != nil {
+		b.desc.checkZeroValue(uint64Type)
+	}
 	return b.desc
 }
 
@@ -1937,6 +2404,13 @@ func (b *float64Builder) Comment(c string) *float64Builder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *float64Builder) DisplayName(name string) *float64Builder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // Optional indicates that this field is optional on create.
 // Unlike edges, fields are required by default.
 func (b *float64Builder) Optional() *float64Builder {
@@ -1962,6 +2436,27 @@ func (b *float64Builder) Validate(fn func(float64) error) *float64Builder {
 	return b
 }
 
+// ContextValidation adds a validator for this field that requires the mutation context, for
+// example, for validators that query the database. Unlike Validate, that is called by the
+// builders before save, context validators are called by the client hooks on mutation
+// execution with the context of the mutation.
+func (b *float64Builder) ContextValidation(fn func(context.Context, float64) error) *float64Builder {
+	b.desc.ContextValidators = append(b.desc.ContextValidators, fn)
+	return b
+}
+
+// Counter marks the field as a counter that can only be incremented on updates. The
+// update builders of the field are generated with an Incr method instead of its Set and
+// Add methods, and updates that set the field or decrement it are rejected.
+//
+//	field.Float64("views").
+//		Counter()
+//
+func (b *float64Builder) Counter() *float64Builder {
+	b.desc.Counter = true
+	return b
+}
+
 // StorageKey sets the storage key of the field.
 // In SQL dialects is the column name and Gremlin is the property.
 func (b *float64Builder) StorageKey(key string) *float64Builder {
@@ -2001,6 +2496,20 @@ func (b *float64Builder) GoType(typ interface{}) *float64Builder {
 	return b
 }
 
+// GoZeroValue sets the Go value that represents a NULL value of this optional
+// field. NULL columns are read into the entity as this value, and setting the
+// field to it stores NULL in the database.
+//
+//	field.Float64("float64").
+//		GoType(pkg.Float64(0)).
+//		GoZeroValue(pkg.Float64(-1)).
+//		Optional()
+//
+func (b *float64Builder) GoZeroValue(v interface{}) *float64Builder {
+	b.desc.ZeroValue = v
+	return b
+}
+
 // Annotations adds a list of annotations to the field object to be used by
 // codegen extensions.
 //
@@ -2014,6 +2523,9 @@ func (b *float64Builder) Annotations(annotations ...schema.Annotation) *float64B
 
 // Descriptor implements the ent.Field interface by returning its descriptor.
 func (b *float64Builder) Descriptor() *Descriptor {
+	if b.desc.ZeroValue != nil {
+		b.desc.checkZeroValue(float64Type)
+	}
 	return b.desc
 }
 
@@ -2090,6 +2602,13 @@ func (b *float32Builder) Comment(c string) *float32Builder {
 	return b
 }
 
+// DisplayName sets a human-readable name of the field that can be used
+// by codegen extensions (e.g. form or admin UI generators).
+func (b *float32Builder) DisplayName(name string) *float32Builder {
+	b.desc.DisplayName = name
+	return b
+}
+
 // Optional indicates that this field is optional on create.
 // Unlike edges, fields are required by default.
 func (b *float32Builder) Optional() *float32Builder {
@@ -2115,6 +2634,27 @@ func (b *float32Builder) Validate(fn func(float32) error) *float32Builder {
 	return b
 }
 
+// ContextValidation adds a validator for this field that requires the mutation context, for
+// example, for validators that query the database. Unlike Validate, that is called by the
+// builders before save, context validators are called by the client hooks on mutation
+// execution with the context of the mutation.
+func (b *float32Builder) ContextValidation(fn func(context.Context, float32) error) *float32Builder {
+	b.desc.ContextValidators = append(b.desc.ContextValidators, fn)
+	return b
+}
+
+// Counter marks the field as a counter that can only be incremented on updates. The
+// update builders of the field are generated with an Incr method instead of its Set and
+// Add methods, and updates that set the field or decrement it are rejected.
+//
+//	field.Float32("views").
+//		Counter()
+//
+func (b *float32Builder) Counter() *float32Builder {
+	b.desc.Counter = true
+	return b
+}
+
 // StorageKey sets the storage key of the field.
 // In SQL dialects is the column name and Gremlin is the property.
 func (b *float32Builder) StorageKey(key string) *float32Builder {
@@ -2154,6 +2694,20 @@ func (b *float32Builder) GoType(typ interface{}) *float32Builder {
 	return b
 }
 
+// GoZeroValue sets the Go value that represents a NULL value of this optional
+// field. NULL columns are read into the entity as this value, and setting the
+// field to it stores NULL in the database.
+//
+//	field.Float32("float32").
+//		GoType(pkg.Float32(0)).
+//		GoZeroValue(pkg.Float32(-1)).
+//		Optional()
+//
+func (b *float32Builder) GoZeroValue(v interface{}) *float32Builder {
+	b.desc.ZeroValue = v
+	return b
+}
+
 // Annotations adds a list of annotations to the field object to be used by
 // codegen extensions.
 //
@@ -2167,6 +2721,9 @@ func (b *float32Builder) Annotations(annotations ...schema.Annotation) *float32B
 
 // Descriptor implements the ent.Field interface by returning its descriptor.
 func (b *float32Builder) Descriptor() *Descriptor {
+	if b.desc.ZeroValue != nil {
+		b.desc.checkZeroValue(float32Type)
+	}
 	return b.desc
 }
 
@@ -0,0 +1,78 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Phone returns a new string field for storing phone numbers in E.164 format (e.g. "+14155552671").
+// Values are normalized with NormalizePhone before they are stored, and validated with ValidatePhone.
+// The generated entities have an additional <Field>Formatted method for displaying the number in a
+// locale-specific format using FormatPhone.
+//
+//	field.Phone("mobile").
+//		Optional()
+//
+func Phone(name string) *stringBuilder {
+	b := String(name)
+	b.desc.Size = 16
+	b.desc.Phone = true
+	return b.Validate(func(s string) error {
+		return ValidatePhone(s)
+	})
+}
+
+// The following functions are used by phone fields, and can be replaced with implementations
+// that are based on libphonenumber. For example:
+//
+//	field.NormalizePhone = func(s string) string {
+//		n, err := phonenumbers.Parse(s, "US")
+//		if err != nil {
+//			return s
+//		}
+//		return phonenumbers.Format(n, phonenumbers.E164)
+//	}
+//
+var (
+	// NormalizePhone normalizes the phone numbers before they are stored. By default, it removes
+	// formatting characters (spaces, dashes, dots and parentheses), and replaces the "00" prefix
+	// of international numbers with "+".
+	NormalizePhone = normalizePhone
+
+	// ValidatePhone validates the normalized phone numbers. By default, it checks that the numbers
+	// are in E.164 format: a "+" sign followed by up to 15 digits, without a leading zero.
+	ValidatePhone = validatePhone
+
+	// FormatPhone formats the stored phone numbers for display in the given locale (e.g. "en-US").
+	// By default, it returns the numbers in E.164 format as they are, regardless of the locale.
+	FormatPhone = formatPhone
+)
+
+var (
+	phoneReplacer = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+	e164Regexp    = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+)
+
+func normalizePhone(s string) string {
+	s = phoneReplacer.Replace(strings.TrimSpace(s))
+	if strings.HasPrefix(s, "00") {
+		s = "+" + s[2:]
+	}
+	return s
+}
+
+func validatePhone(s string) error {
+	if !e164Regexp.MatchString(s) {
+		return errors.New("invalid E.164 phone number")
+	}
+	return nil
+}
+
+func formatPhone(s, _ string) string {
+	return s
+}
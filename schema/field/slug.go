@@ -0,0 +1,61 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Slugify is used by AutoSlug fields for converting their source values to slugs,
+// and can be replaced with a custom implementation. For example:
+//
+//	field.Slugify = slug.Make
+//
+// By default, it transliterates latin letters to ASCII (e.g. "Crème Brûlée" becomes
+// "creme-brulee"), lowercases letters and replaces all other characters with dashes.
+var Slugify = slugify
+
+// translit holds the latin letters that are not decomposed by NFD.
+var translit = map[rune]string{
+	'ß': "ss",
+	'æ': "ae",
+	'ø': "o",
+	'œ': "oe",
+	'đ': "d",
+	'ð': "d",
+	'ł': "l",
+	'þ': "th",
+	'ı': "i",
+}
+
+func slugify(s string) string {
+	var (
+		b    strings.Builder
+		dash bool
+	)
+	write := func(v string) {
+		if dash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteString(v)
+		dash = false
+	}
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Drop the combining marks (accents) of decomposed letters.
+		case translit[r] != "":
+			write(translit[r])
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			write(string(r))
+		default:
+			dash = true
+		}
+	}
+	return b.String()
+}
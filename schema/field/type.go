@@ -192,6 +192,8 @@ type RType struct {
 	Kind    reflect.Kind
 	PkgPath string
 	Methods map[string]struct{ In, Out []*RType }
+	// Elem holds the element type of slice types.
+	Elem *RType `json:",omitempty"`
 	// Used only for in-package checks.
 	rtype reflect.Type
 }
@@ -0,0 +1,90 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package field
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// URL returns a new string field for storing absolute URLs (e.g. "https://entgo.io/docs").
+// Values are normalized with NormalizeURL before they are stored, and validated with ValidateURL.
+// The column is limited to 2083 characters, the maximum URL length that is supported by browsers.
+//
+//	field.URL("website").
+//		Optional()
+//
+func URL(name string) *stringBuilder {
+	b := String(name)
+	b.desc.Size = 2083
+	b.desc.URL = true
+	return b.Validate(func(s string) error {
+		return ValidateURL(s)
+	})
+}
+
+// The following functions are used by URL fields, and can be replaced
+// with custom implementations. For example:
+//
+//	field.ValidateURL = func(s string) error {
+//		u, err := url.Parse(s)
+//		if err != nil {
+//			return err
+//		}
+//		if u.Scheme != "https" {
+//			return errors.New("only https URLs are allowed")
+//		}
+//		return nil
+//	}
+//
+var (
+	// NormalizeURL normalizes the URLs before they are stored. By default, it lowercases
+	// their scheme and host, and removes the trailing slash of their path. Internationalized
+	// hosts are kept in their Unicode form (e.g. "https://bücher.example").
+	NormalizeURL = normalizeURL
+
+	// ValidateURL validates the normalized URLs. By default, it checks that the
+	// URLs can be parsed by url.Parse, and that they have a scheme and a host.
+	ValidateURL = validateURL
+)
+
+func normalizeURL(s string) string {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return s
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = strings.TrimSuffix(u.RawPath, "/")
+	if u.Host == "" {
+		return u.String()
+	}
+	// Hosts are case-insensitive, and internationalized hosts are kept
+	// in their Unicode form, as url.URL.String percent-encodes them.
+	var b strings.Builder
+	if u.Scheme != "" {
+		b.WriteString(u.Scheme + ":")
+	}
+	b.WriteString("//")
+	if u.User != nil {
+		b.WriteString(u.User.String() + "@")
+	}
+	b.WriteString(strings.ToLower(u.Host))
+	u.Scheme, u.User, u.Host = "", nil, ""
+	b.WriteString(u.String())
+	return b.String()
+}
+
+func validateURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return errors.New("invalid URL: missing scheme or host")
+	}
+	return nil
+}
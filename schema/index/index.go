@@ -12,6 +12,8 @@ type Descriptor struct {
 	Edges       []string            // edge columns.
 	Fields      []string            // field columns.
 	StorageKey  string              // custom index name.
+	Expr        string              // index expression.
+	Concurrent  bool                // concurrent index creation.
 	Annotations []schema.Annotation // index annotations.
 }
 
@@ -56,6 +58,24 @@ func Edges(edges ...string) *Builder {
 	return &Builder{desc: &Descriptor{Edges: edges}}
 }
 
+// Expr creates a function-based index (also known as expression index) on the given
+// SQL expression. The expression should reference exactly one field column in order to
+// generate its companion predicates, and the index must be named explicitly.
+// Note that expression indexes are supported only by SQL dialects (MySQL 8, Postgres and SQLite).
+//
+//	func (T) Indexes() []ent.Index {
+//
+//		// Index on the month of the "created_at" field. Generates
+//		// the user.CreatedAtMonthEQ predicate that uses the index.
+//		index.Expr("DATE_TRUNC('month', created_at)").
+//			Named("idx_users_month"),
+//
+//	}
+//
+func Expr(expr string) *Builder {
+	return &Builder{desc: &Descriptor{Expr: expr}}
+}
+
 // Fields sets the fields of the index.
 //
 //	func (T) Indexes() []ent.Index {
@@ -94,12 +114,35 @@ func (b *Builder) Unique() *Builder {
 	return b
 }
 
+// Concurrent sets the index to be created concurrently, without blocking
+// writes on its table. In Postgres, the index is created using the CREATE
+// INDEX CONCURRENTLY statement, outside the migration transaction. Other
+// dialects ignore this option and create the index regularly.
+//
+//	func (T) Indexes() []ent.Index {
+//
+//		// Create the index without locking the table against writes.
+//		index.Fields("email").
+//			Concurrent(),
+//
+//	}
+//
+func (b *Builder) Concurrent() *Builder {
+	b.desc.Concurrent = true
+	return b
+}
+
 // StorageKey sets the storage key of the index. In SQL dialects, it's the index name.
 func (b *Builder) StorageKey(key string) *Builder {
 	b.desc.StorageKey = key
 	return b
 }
 
+// Named sets the name of the index. It's an alias for StorageKey.
+func (b *Builder) Named(name string) *Builder {
+	return b.StorageKey(name)
+}
+
 // Annotations adds a list of annotations to the index object to be used by codegen extensions.
 //
 //	func (T) Indexes() []ent.Index {
@@ -36,4 +36,17 @@ func TestIndex(t *testing.T) {
 	require.Equal(t, []string{"parent", "type"}, idx.Edges)
 	require.True(t, idx.Unique)
 	require.Equal(t, []string{"name", "address"}, idx.Fields)
+
+	idx = index.Expr("DATE_TRUNC('month', created_at)").
+		Named("idx_users_month").
+		Descriptor()
+	require.Empty(t, idx.Fields)
+	require.Equal(t, "DATE_TRUNC('month', created_at)", idx.Expr)
+	require.Equal(t, "idx_users_month", idx.StorageKey)
+
+	idx = index.Fields("email").
+		Concurrent().
+		Descriptor()
+	require.True(t, idx.Concurrent)
+	require.Equal(t, []string{"email"}, idx.Fields)
 }
@@ -86,6 +86,52 @@ func (Time) Fields() []ent.Field {
 // time mixin must implement `Mixin` interface.
 var _ ent.Mixin = (*Time)(nil)
 
+// SoftDelete adds a deleted at time field, and configures the codegen to
+// mark the entities as deleted by setting this field instead of deleting
+// them from the database.
+//
+//	// Mixin of the user.
+//	func (User) Mixin() []ent.Mixin {
+//		return []ent.Mixin{
+//			mixin.SoftDelete{},
+//		}
+//	}
+//
+type SoftDelete struct{ Schema }
+
+// Fields of the soft delete mixin.
+func (SoftDelete) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("delete_time").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Annotations of the soft delete mixin.
+func (SoftDelete) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		SoftDeleteAnnotation{Field: "delete_time"},
+	}
+}
+
+// soft delete mixin must implement `Mixin` interface.
+var _ ent.Mixin = (*SoftDelete)(nil)
+
+// SoftDeleteAnnotation is a schema annotation that configures the codegen to
+// mark the entities of the schema as deleted by setting the given time field,
+// instead of deleting them from the database. It is added by the SoftDelete mixin.
+type SoftDeleteAnnotation struct {
+	// Field is the name of the optional time field that
+	// holds the time the entity was marked as deleted.
+	Field string
+}
+
+// Name describes the annotation name.
+func (SoftDeleteAnnotation) Name() string {
+	return "SoftDelete"
+}
+
 // AnnotateFields adds field annotations to underlying mixin fields.
 func AnnotateFields(m ent.Mixin, annotations ...schema.Annotation) ent.Mixin {
 	return fieldAnnotator{Mixin: m, annotations: annotations}
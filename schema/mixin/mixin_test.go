@@ -45,6 +45,19 @@ func TestTimeMixin(t *testing.T) {
 	})
 }
 
+func TestSoftDeleteMixin(t *testing.T) {
+	fields := mixin.SoftDelete{}.Fields()
+	require.Len(t, fields, 1)
+	desc := fields[0].Descriptor()
+	assert.Equal(t, "delete_time", desc.Name)
+	assert.True(t, desc.Optional)
+	assert.True(t, desc.Nillable)
+	assert.Nil(t, desc.Default)
+	annotations := mixin.SoftDelete{}.Annotations()
+	require.Len(t, annotations, 1)
+	assert.Equal(t, mixin.SoftDeleteAnnotation{Field: "delete_time"}, annotations[0])
+}
+
 type annotation string
 
 func (annotation) Name() string { return "" }
@@ -4,6 +4,8 @@
 
 package schema
 
+import "reflect"
+
 // Annotation is used to attach arbitrary metadata to the schema objects in codegen.
 // The object must be serializable to JSON raw value (e.g. struct, map or slice).
 //
@@ -22,3 +24,45 @@ type Annotation interface {
 type Merger interface {
 	Merge(Annotation) Annotation
 }
+
+// Widget is a builtin schema annotation for hinting schema-driven UIs (e.g. admin
+// UIs and form generators) which input widget to render for a field. For example:
+//
+//	field.String("color").
+//		Annotations(schema.Widget{Type: "color-picker"})
+//
+//	field.Time("scheduled_at").
+//		Annotations(schema.Widget{Type: "datetime-local"})
+//
+type Widget struct {
+	// Type of the widget. For example, "textarea", "color-picker" or "datetime-local".
+	Type string `json:"type,omitempty"`
+	// Options holds additional options for rendering the widget.
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+// Name describes the annotation name.
+func (Widget) Name() string {
+	return "Widget"
+}
+
+// PolymorphicTypes holds the names of the types that a polymorphic edge can point to.
+// See the Polymorphic function for more info.
+type PolymorphicTypes []string
+
+// Polymorphic returns the types of a polymorphic edge. It is passed to edge.From instead
+// of a single type for defining an edge that can point to entities of multiple types. The
+// edge is stored in a pair of "<edge>_type" and "<edge>_id" columns, and all types must
+// share the same ID type. For example:
+//
+//	edge.From("commentable", schema.Polymorphic(Post.Type, Video.Type))
+//
+func Polymorphic(types ...interface{}) PolymorphicTypes {
+	names := make(PolymorphicTypes, 0, len(types))
+	for _, t := range types {
+		if rt := reflect.TypeOf(t); rt != nil && rt.Kind() == reflect.Func && rt.NumIn() > 0 {
+			names = append(names, rt.In(0).Name())
+		}
+	}
+	return names
+}